@@ -0,0 +1,369 @@
+// The yaaf-code-gen command line tool
+//
+// Usage:
+//
+//	yaaf-code-gen model [-filter path] -o <file> <source folder>
+//	yaaf-code-gen diff [-format text|json] [-filter path] <old> <new>
+//	yaaf-code-gen changelog [-title text] [-filter path] -o <folder> <old> <new>
+//	yaaf-code-gen docs [-format html|markdown] [-base-url url] [-prefix path] [-filter path] -o <folder> <source>
+//	yaaf-code-gen postman [-name text] [-base-url url] [-filter path] -o <folder> <source>
+//	yaaf-code-gen mock [-addr address] [-random] [-seed n] [-filter path] <source>
+//	yaaf-code-gen routes [-format text|json] [-prefix path] [-filter path] <source> <router folder>
+//
+// The model command parses the source folder and saves the model to JSON or YAML file (by the file extension).
+//
+// The diff command compares two versions of the API model and lists the changes of services, methods, classes, fields
+// and enum values. Each version is either a source folder (parsed by the code generator) or saved model file.
+// The command exits with code 1 when breaking changes are found (can be used to gate merges in CI) and code 2 on errors.
+//
+// The changelog command renders the changes between two versions of the API model as Markdown and HTML release notes.
+//
+// The docs command writes static HTML documentation site of the API model (source folder or saved model file), the
+// resource pages include API explorer forms sending requests to the base URL. With -format markdown it writes Markdown
+// pages and sidebars for MkDocs and Docusaurus instead (prefix is the output folder path relative to the docs root).
+//
+// The postman command writes Postman collection and environment, and .http request files (JetBrains / VS Code) of the
+// API model services.
+//
+// The mock command runs HTTP server of the API model services: requests are validated against the model and answered
+// with the methods examples (or random values of the return types with -random), so clients can be developed before
+// the backend exists.
+//
+// The routes command scans the Go code of hand-written routers (net/http, gorilla/mux, chi, gin, echo and fiber) for
+// route registrations and reports routes which are registered but not annotated, annotated but not registered, or
+// registered with other verb or path than the annotation. The command exits with code 1 when drift is found.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	generator "github.com/go-yaaf/yaaf-code-gen"
+	"github.com/go-yaaf/yaaf-code-gen/mock"
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+	"github.com/go-yaaf/yaaf-code-gen/routes"
+)
+
+const usage = `Usage: yaaf-code-gen <command> [options]
+
+Commands:
+  model [-filter path] -o <file> <source folder>
+        Parse the source folder and save the model to JSON or YAML file (by the file extension)
+  diff [-format text|json] [-filter path] <old> <new>
+        Compare two versions of the API model (source folder or saved model file) and report breaking changes
+  changelog [-title text] [-filter path] -o <folder> <old> <new>
+        Write Markdown and HTML changelog (changelog.md, changelog.html) of two versions of the API model
+  docs [-format html|markdown] [-base-url url] [-prefix path] [-filter path] -o <folder> <source>
+        Write HTML documentation and API explorer, or Markdown pages for MkDocs and Docusaurus, of the API model
+  postman [-name text] [-base-url url] [-filter path] -o <folder> <source>
+        Write Postman collection and environment, and .http request files of the API model services
+  mock [-addr address] [-random] [-seed n] [-filter path] <source>
+        Run mock HTTP server of the API model services returning example or random responses
+  routes [-format text|json] [-prefix path] [-filter path] <source> <router folder>
+        Compare the @Http annotations of the API model with the route registrations of the router source code
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "model":
+		os.Exit(runModel(os.Args[2:]))
+	case "diff":
+		os.Exit(runDiff(os.Args[2:]))
+	case "changelog":
+		os.Exit(runChangelog(os.Args[2:]))
+	case "docs":
+		os.Exit(runDocs(os.Args[2:]))
+	case "postman":
+		os.Exit(runPostman(os.Args[2:]))
+	case "mock":
+		os.Exit(runMock(os.Args[2:]))
+	case "routes":
+		os.Exit(runRoutes(os.Args[2:]))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+// Run the model command and return the exit code
+func runModel(args []string) int {
+	flags := flag.NewFlagSet("model", flag.ExitOnError)
+	output := flags.String("o", "", "output file: .json | .yaml | .yml")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 || len(*output) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	mm, err := loadModel(flags.Arg(0), *filter)
+	if err == nil {
+		err = model.Save(mm, *output)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	return 0
+}
+
+// Run the diff command and return the exit code
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := flags.String("format", "text", "output format: text | json")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	prev, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	curr, err := loadModel(flags.Arg(1), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	changes := model.Diff(prev, curr)
+	switch *format {
+	case "json":
+		bytes, _ := json.MarshalIndent(changes, "", "  ")
+		fmt.Println(string(bytes))
+	case "text":
+		printChanges(changes)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return 2
+	}
+
+	if model.HasBreakingChanges(changes) {
+		return 1
+	}
+	return 0
+}
+
+// Run the changelog command and return the exit code
+func runChangelog(args []string) int {
+	flags := flag.NewFlagSet("changelog", flag.ExitOnError)
+	output := flags.String("o", "", "output folder")
+	title := flags.String("title", "API Changelog", "changelog title (e.g. the release version)")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 || len(*output) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	prev, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	curr, err := loadModel(flags.Arg(1), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	if err = processor.NewChangelogProcessor(curr, *output, prev).WithTitle(*title).Start(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	return 0
+}
+
+// Run the docs command and return the exit code
+func runDocs(args []string) int {
+	flags := flag.NewFlagSet("docs", flag.ExitOnError)
+	output := flags.String("o", "", "output folder")
+	format := flags.String("format", "html", "output format: html | markdown")
+	baseURL := flags.String("base-url", "", "default base URL of the API explorer requests (html)")
+	prefix := flags.String("prefix", "", "output folder path relative to the docs root folder, used in the sidebars (markdown)")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 || len(*output) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	mm, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	var docs processor.Processor
+	switch *format {
+	case "html":
+		docs = processor.NewHtmlProcessor(mm, *output).WithBaseURL(*baseURL)
+	case "markdown":
+		docs = processor.NewMarkdownProcessor(mm, *output).WithPrefix(*prefix)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return 2
+	}
+	if err = docs.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	return 0
+}
+
+// Run the postman command and return the exit code
+func runPostman(args []string) int {
+	flags := flag.NewFlagSet("postman", flag.ExitOnError)
+	output := flags.String("o", "", "output folder")
+	name := flags.String("name", "API", "collection name")
+	baseURL := flags.String("base-url", "", "default value of the baseUrl environment variable")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 || len(*output) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	mm, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	if err = processor.NewPostmanProcessor(mm, *output).WithName(*name).WithBaseURL(*baseURL).Start(); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	return 0
+}
+
+// Run the mock command and return the exit code (the server runs until it fails)
+func runMock(args []string) int {
+	flags := flag.NewFlagSet("mock", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "server listen address")
+	random := flags.Bool("random", false, "return random responses instead of the examples")
+	seed := flags.Int64("seed", time.Now().UnixNano(), "seed of the random responses")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	mm, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	server := mock.NewServer(mm)
+	if *random {
+		server.WithRandom(*seed)
+	}
+	fmt.Printf("mock server listening on %s\n", *addr)
+	if err = http.ListenAndServe(*addr, server); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	return 0
+}
+
+// Run the routes command and return the exit code
+func runRoutes(args []string) int {
+	flags := flag.NewFlagSet("routes", flag.ExitOnError)
+	format := flags.String("format", "text", "output format: text | json")
+	prefix := flags.String("prefix", "", "path prefix of the registered routes which is not part of the annotations (e.g. /api/v1)")
+	filter := flags.String("filter", "", "process only source files that their path includes the filter")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	mm, err := loadModel(flags.Arg(0), *filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	registered, err := routes.ScanFolder(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	drifts := routes.Compare(mm, registered, *prefix)
+	switch *format {
+	case "json":
+		bytes, _ := json.MarshalIndent(drifts, "", "  ")
+		fmt.Println(string(bytes))
+	case "text":
+		for _, d := range drifts {
+			fmt.Println(d.String())
+		}
+		fmt.Printf("%s\n%d routes registered, %d drifts\n", strings.Repeat("-", 40), len(registered), len(drifts))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format: %s\n", *format)
+		return 2
+	}
+
+	if len(drifts) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// Load model from source folder or saved model file
+func loadModel(source string, filter string) (*model.MetaModel, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		cg := generator.NewCodeGenerator().WithSourceFolder(source, "").WithPathFilter(filter)
+		if err = cg.Parse(); err != nil {
+			return nil, err
+		}
+		return cg.Model, nil
+	}
+	return model.Load(source)
+}
+
+// Print changes as text, breaking changes first
+func printChanges(changes []*model.Change) {
+	breaking, compatible := 0, 0
+	for _, c := range changes {
+		if c.Breaking {
+			fmt.Println(c.String())
+			breaking++
+		}
+	}
+	for _, c := range changes {
+		if !c.Breaking {
+			fmt.Println(c.String())
+			compatible++
+		}
+	}
+	fmt.Printf("%s\n%d breaking, %d compatible changes\n", strings.Repeat("-", 40), breaking, compatible)
+}
@@ -15,16 +15,19 @@ import (
 
 // CodeGenerator is the main tool to parse source folder
 type CodeGenerator struct {
-	sourceFolders map[string]string // Map of source folders to namespaces
-	targetFolder  string            // Root target folder for the artifacts
-	pathFilter    string            // Filter to process only files that their path includes the filter
-	Model         *model.MetaModel  // The generated abstract model
+	sourceFolders map[string]string     // Map of source folders to namespaces
+	modelFile     string                // Saved model file to load (instead of or in addition to the source folders)
+	targetFolder  string                // Root target folder for the artifacts
+	pathFilter    string                // Filter to process only files that their path includes the filter
+	processors    []processor.Processor // Additional artifacts processors
+	Model         *model.MetaModel      // The generated abstract model
 }
 
 func NewCodeGenerator() *CodeGenerator {
 	return &CodeGenerator{
 		Model:         model.NewMetaModel(),
 		sourceFolders: make(map[string]string),
+		processors:    make([]processor.Processor, 0),
 	}
 }
 
@@ -34,6 +37,12 @@ func (cg *CodeGenerator) WithSourceFolder(path string, pkg string) *CodeGenerato
 	return cg
 }
 
+// WithModelFile sets the model file (saved by model.Save) to load instead of parsing Go source files
+func (cg *CodeGenerator) WithModelFile(fileName string) *CodeGenerator {
+	cg.modelFile = fileName
+	return cg
+}
+
 // WithTargetFolder sets the target artifacts folders
 func (cg *CodeGenerator) WithTargetFolder(path string) *CodeGenerator {
 	cg.targetFolder = path
@@ -64,8 +73,30 @@ func (cg *CodeGenerator) WithServiceTemplate(template string, funcMap template.F
 	return cg
 }
 
-// Process the source folders and generate artifacts
-func (cg *CodeGenerator) Process() error {
+// WithProcessor adds artifacts processor to run after the TypeScript processor (processor should be created with the generator Model)
+func (cg *CodeGenerator) WithProcessor(p processor.Processor) *CodeGenerator {
+	cg.processors = append(cg.processors, p)
+	return cg
+}
+
+// WithHtmlDocs adds the HTML documentation processor writing static documentation site to the output folder
+func (cg *CodeGenerator) WithHtmlDocs(output string) *CodeGenerator {
+	return cg.WithProcessor(processor.NewHtmlProcessor(cg.Model, output))
+}
+
+// Parse the source folders and fill the metamodel (without generating artifacts)
+func (cg *CodeGenerator) Parse() error {
+
+	// load the saved model, the processors refer to the generator Model, so it is filled in place
+	if len(cg.modelFile) > 0 {
+		mm, err := model.Load(cg.modelFile)
+		if err != nil {
+			return fmt.Errorf("failed to load model: %s", err.Error())
+		}
+		for name, pkg := range mm.Packages {
+			cg.Model.Packages[name] = pkg
+		}
+	}
 
 	// run the file parser to fill the metamodel
 	if err := cg.parseSourceFiles(); err != nil {
@@ -77,9 +108,21 @@ func (cg *CodeGenerator) Process() error {
 
 	// fill the dependencies
 	cg.Model.FillDependencies()
+	return nil
+}
+
+// Process the source folders and generate artifacts
+func (cg *CodeGenerator) Process() error {
+
+	if err := cg.Parse(); err != nil {
+		return err
+	}
 
 	// generate the artifacts
-	return cg.createTSFiles()
+	if err := cg.createTSFiles(); err != nil {
+		return err
+	}
+	return cg.runProcessors()
 }
 
 // Parse all files in the list of folders and fill the metamodel
@@ -92,6 +135,9 @@ func (cg *CodeGenerator) parseSourceFiles() error {
 			return err
 		}
 	}
+	for _, warning := range fileParser.Warnings {
+		fmt.Println("warning", warning)
+	}
 	return nil
 }
 
@@ -130,8 +176,21 @@ func (cg *CodeGenerator) checkFilter(filePath string) bool {
 	return false
 }
 
-// Create Typescript files
+// Create Typescript files (only when target folder is set)
 func (cg *CodeGenerator) createTSFiles() error {
+	if len(cg.targetFolder) == 0 {
+		return nil
+	}
 	p := processor.NewTsProcessor(cg.Model, cg.targetFolder)
 	return p.Start()
 }
+
+// Run all additional processors
+func (cg *CodeGenerator) runProcessors() error {
+	for _, p := range cg.processors {
+		if err := p.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
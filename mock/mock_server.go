@@ -0,0 +1,398 @@
+// Package mock serves mock responses of the API model services, so clients can be developed before the backend exists
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+// region Server structure ---------------------------------------------------------------------------------------------
+
+// Server is http.Handler routing the requests to the API model service methods by the HTTP verb and path template.
+// The path, query and body parameters are validated against the model (invalid requests get 400 Bad Request) and the
+// response is the method @Example or example synthesized from the method return type (random values in random mode).
+type Server struct {
+	mm     *model.MetaModel
+	routes []*route
+	random *rand.Rand
+	mu     sync.Mutex // guards the random source
+}
+
+// route is service method route, the path template is split to segments ({name} segment matches any value)
+type route struct {
+	service  *model.ServiceInfo
+	method   *model.MethodInfo
+	segments []string
+}
+
+// NewServer - Factory method
+func NewServer(mm *model.MetaModel) *Server {
+	s := &Server{mm: mm, routes: make([]*route, 0)}
+	for _, service := range mm.ListServices() {
+		for _, mi := range service.Methods {
+			if mi.IsSocketMessage || len(mi.Method) == 0 {
+				continue
+			}
+			s.routes = append(s.routes, &route{service: service, method: mi, segments: splitPath(service.MethodPath(mi))})
+		}
+	}
+	return s
+}
+
+// WithRandom enables random mode, responses are generated with random values (the seed makes them reproducible)
+// instead of the examples
+func (s *Server) WithRandom(seed int64) *Server {
+	s.random = rand.New(rand.NewSource(seed))
+	return s
+}
+
+// endregion
+
+// region Request handling ---------------------------------------------------------------------------------------------
+
+// ServeHTTP handles the request: route, validate and write the mock response
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	rt, params, allowed := s.match(r.Method, r.URL.Path)
+	if r.Method == http.MethodOptions && len(allowed) > 0 {
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(append(allowed, http.MethodOptions), ", "))
+		w.Header().Set("Access-Control-Allow-Headers", "*")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if rt == nil {
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed: %s", r.Method, r.URL.Path))
+		} else {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no route: %s %s", r.Method, r.URL.Path))
+		}
+		return
+	}
+
+	if err := s.validate(rt.method, params, r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if rt.method.ReturnClass == "StreamContent" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("mock content"))
+		return
+	}
+	if rt.method.ReturnType == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	response := s.response(rt.method)
+	if len(response) == 0 {
+		response = "{}"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(response + "\n"))
+}
+
+// Find the route of the request: the route with the most literal segments among the routes matching the path and verb,
+// the path parameters by name and the verbs allowed for the path
+func (s *Server) match(verb string, path string) (*route, map[string]string, []string) {
+	segments := splitPath(path)
+
+	var (
+		best    *route
+		params  map[string]string
+		score   = -1
+		allowed = make([]string, 0)
+	)
+	for _, rt := range s.routes {
+		values, literals, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(allowed, rt.method.Method) {
+			allowed = append(allowed, rt.method.Method)
+		}
+		if rt.method.Method == verb && literals > score {
+			best, params, score = rt, values, literals
+		}
+	}
+	return best, params, allowed
+}
+
+// Match path segments to the route template, returns the path parameter values and the number of literal segments
+func (rt *route) match(segments []string) (map[string]string, int, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, 0, false
+	}
+	values := make(map[string]string)
+	literals := 0
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			values[strings.Trim(segment, "{}")] = segments[i]
+		} else if segment == segments[i] {
+			literals++
+		} else {
+			return nil, 0, false
+		}
+	}
+	return values, literals, true
+}
+
+// Build the method response (random values in random mode)
+func (s *Server) response(mi *model.MethodInfo) string {
+	if s.random == nil {
+		return processor.NewExampleBuilder(s.mm).Response(mi)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return processor.NewExampleBuilder(s.mm).WithRandom(s.random).Response(mi)
+}
+
+// endregion
+
+// region Validation ---------------------------------------------------------------------------------------------------
+
+// Validate the request parameters against the method parameters (body is not sent with GET and DELETE requests)
+func (s *Server) validate(mi *model.MethodInfo, params map[string]string, r *http.Request) error {
+	for _, pi := range mi.PathParams {
+		value, ok := params[pi.Name]
+		if !ok {
+			value, ok = params[pi.Json]
+		}
+		if !ok {
+			continue
+		}
+		if err := s.validateText(pi.Type, value); err != nil {
+			return fmt.Errorf("path parameter %s: %s", pi.Json, err)
+		}
+	}
+
+	query := r.URL.Query()
+	for _, pi := range mi.QueryParams {
+		for _, value := range query[pi.Json] {
+			values := []string{value}
+			if pi.IsArray || strings.HasPrefix(pi.Type, "[]") {
+				values = strings.Split(value, ",")
+			}
+			for _, item := range values {
+				if err := s.validateText(strings.TrimPrefix(pi.Type, "[]"), item); err != nil {
+					return fmt.Errorf("query parameter %s: %s", pi.Json, err)
+				}
+			}
+		}
+	}
+
+	if mi.FileParam != nil {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return fmt.Errorf("file parameter %s: multipart form expected: %s", mi.FileParam.Json, err)
+		}
+		if _, _, err := r.FormFile("fileKey"); err != nil {
+			return fmt.Errorf("file parameter %s: missing fileKey form file", mi.FileParam.Json)
+		}
+	}
+
+	if processor.HasRequestBody(mi) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("error reading body: %s", err)
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			return fmt.Errorf("body parameter %s is required", mi.BodyParam.Json)
+		}
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err = decoder.Decode(&value); err != nil {
+			return fmt.Errorf("body parameter %s: invalid JSON: %s", mi.BodyParam.Json, err)
+		}
+		node := model.NewTypeNode(mi.BodyParam.Type)
+		if node == nil {
+			return nil
+		}
+		node.IsArray = node.IsArray || mi.BodyParam.IsArray
+		if err = s.validateJson(node, value, mi.BodyParam.Json); err != nil {
+			return fmt.Errorf("body %s", err)
+		}
+	}
+	return nil
+}
+
+// Validate path or query parameter value of primitive or enum type
+func (s *Server) validateText(typeName string, value string) error {
+	if enum := s.mm.GetEnum(typeName); enum != nil {
+		number, err := strconv.Atoi(value)
+		if err != nil || !enumHasValue(enum, number) {
+			return fmt.Errorf("invalid %s value: %s", enum.Name, value)
+		}
+		return nil
+	}
+	switch model.GetTsType(typeName) {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil || (isInteger(typeName) && strings.ContainsAny(value, ".eE")) {
+			return fmt.Errorf("invalid %s value: %s", typeName, value)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid %s value: %s", typeName, value)
+		}
+	}
+	return nil
+}
+
+// Validate JSON value against the type (null is valid value of any type, unknown types are not validated)
+func (s *Server) validateJson(node *model.TypeNode, value any, path string) error {
+	if value == nil {
+		return nil
+	}
+	if node.IsArray {
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: array expected", path)
+		}
+		item := *node
+		item.IsArray = false
+		for i, v := range items {
+			if err := s.validateJson(&item, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if class := s.mm.GetClass(node.Name); class != nil {
+		return s.validateObject(class, value, path)
+	}
+	if enum := s.mm.GetEnum(node.Name); enum != nil {
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: %s value expected", path, enum.Name)
+		}
+		if n, err := number.Int64(); err != nil || !enumHasValue(enum, int(n)) {
+			return fmt.Errorf("%s: invalid %s value: %s", path, enum.Name, number)
+		}
+		return nil
+	}
+
+	switch model.GetTsType(node.Name) {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: string expected", path)
+		}
+	case "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: number expected", path)
+		}
+		if _, err := number.Int64(); err != nil && isInteger(node.Name) {
+			return fmt.Errorf("%s: integer expected", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: boolean expected", path)
+		}
+	case "Record<string,any>":
+		if _, ok := value.(map[string]any); !ok {
+			return fmt.Errorf("%s: object expected", path)
+		}
+	}
+	return nil
+}
+
+// Validate JSON object against the class fields (unknown fields are invalid)
+func (s *Server) validateObject(class *model.ClassInfo, value any, path string) error {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: %s object expected", path, class.Name)
+	}
+
+	fields := make(map[string]*model.FieldInfo)
+	for _, fi := range s.mm.ListClassFields(class) {
+		fields[fi.Json] = fi
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fi, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("%s: unknown field %s", path, key)
+		}
+		if fi.IsMap || isGenericParam(class, fi.Type) {
+			continue
+		}
+		typeName := fi.Type
+		if fi.IsArray && !strings.HasPrefix(typeName, "[]") {
+			typeName = "[]" + typeName
+		}
+		if node := model.NewTypeNodeFromGoType(typeName); node != nil {
+			if err := s.validateJson(node, object[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// Split URL path to segments (empty segments are ignored)
+func splitPath(path string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) > 0 {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// Check if the enum includes the value
+func enumHasValue(enum *model.EnumInfo, value int) bool {
+	for _, ev := range enum.Values {
+		if ev.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if the type is integer number type
+func isInteger(typeName string) bool {
+	return strings.HasPrefix(typeName, "int") || strings.HasPrefix(typeName, "uint") || typeName == "Timestamp"
+}
+
+// Check if the type is generic parameter of the class (e.g. T)
+func isGenericParam(class *model.ClassInfo, typeName string) bool {
+	for _, kv := range class.GenericTypes {
+		if strings.TrimPrefix(typeName, "[]") == kv.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// Write JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	body, _ := json.Marshal(map[string]any{"code": status, "error": message})
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// endregion
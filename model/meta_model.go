@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-yaaf/yaaf-common/entity"
@@ -76,6 +77,18 @@ func (m *MetaModel) GetEnum(name string) *EnumInfo {
 	return nil
 }
 
+// GetClass look for the class by name in all the packages
+func (m *MetaModel) GetClass(name string) *ClassInfo {
+	for _, pkg := range m.Packages {
+		for key, val := range pkg.Classes {
+			if key == name {
+				return val
+			}
+		}
+	}
+	return nil
+}
+
 // GetService look for the service by name in all the packages
 func (m *MetaModel) GetService(name string) *ServiceInfo {
 	for _, pkg := range m.Packages {
@@ -88,6 +101,73 @@ func (m *MetaModel) GetService(name string) *ServiceInfo {
 	return nil
 }
 
+// ListClasses returns all the classes in all the packages sorted by name
+func (m *MetaModel) ListClasses() []*ClassInfo {
+	list := make([]*ClassInfo, 0)
+	for _, pkg := range m.Packages {
+		for _, ci := range pkg.Classes {
+			list = append(list, ci)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// ListEnums returns all the enums in all the packages sorted by name
+func (m *MetaModel) ListEnums() []*EnumInfo {
+	list := make([]*EnumInfo, 0)
+	for _, pkg := range m.Packages {
+		for _, ei := range pkg.Enums {
+			list = append(list, ei)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// ListServices returns all the services in all the packages sorted by name
+func (m *MetaModel) ListServices() []*ServiceInfo {
+	list := make([]*ServiceInfo, 0)
+	for _, pkg := range m.Packages {
+		for _, si := range pkg.Services {
+			list = append(list, si)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// ListWebSockets returns all the web sockets in all the packages sorted by name
+func (m *MetaModel) ListWebSockets() []*WebSocketInfo {
+	list := make([]*WebSocketInfo, 0)
+	for _, pkg := range m.Packages {
+		for _, wi := range pkg.Sockets {
+			list = append(list, wi)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// ListClassFields returns the class fields including the fields of its base classes (base class fields first)
+func (m *MetaModel) ListClassFields(ci *ClassInfo) []*FieldInfo {
+	list := make([]*FieldInfo, 0)
+	visited := make(map[string]bool)
+	for c := ci; c != nil && !visited[c.Name]; c = m.GetClass(c.BaseClass) {
+		visited[c.Name] = true
+		list = append(append(make([]*FieldInfo, 0), c.Fields...), list...)
+	}
+	return list
+}
+
 func (m *MetaModel) String() string {
 	if bytes, err := json.MarshalIndent(m, "", "    "); err != nil {
 		return err.Error()
@@ -62,11 +62,17 @@ func (ci *ClassInfo) fillDependencies(mm *MetaModel) {
 	// Add dependencies for complex fields
 	for _, fi := range ci.Fields {
 		if ci.isGenericFieldType(fi.Type) {
-			ci.fillGenericFieldDependencies(fi.Type)
+			ci.fillGenericFieldDependencies(strings.TrimPrefix(fi.Type, "[]"))
 			for _, genType := range fi.GenericTypes {
 				yTsType := GetTsType(genType.Value)
 				ci.fillFieldDependencies(genType.Value, yTsType)
 			}
+			// fields added by AddField have the generic type arguments only in the type (e.g. EntityResponse[User])
+			if node := NewTypeNodeFromGoType(fi.Type); len(fi.GenericTypes) == 0 && node != nil {
+				for _, arg := range node.Args {
+					ci.fillFieldDependencies(arg.Name, GetTsType(arg.Name))
+				}
+			}
 		} else {
 			ci.fillFieldDependencies(fi.Type, fi.TsType)
 		}
@@ -78,6 +84,13 @@ func (ci *ClassInfo) fillDependencies(mm *MetaModel) {
 }
 
 func (ci *ClassInfo) fillFieldDependencies(fieldType string, fieldTsType string) {
+	if len(fieldType) == 0 {
+		return
+	}
+	// fields added by AddField have no TypeScript type
+	if len(fieldTsType) == 0 {
+		fieldTsType = GetTsType(strings.TrimPrefix(fieldType, "[]"))
+	}
 	isNative, arr := isNativeType(fieldType)
 	if !isNative && !ci.isGenericClassIndex(fieldType) {
 		ci.Dependencies[fieldTsType] = arr
@@ -116,6 +129,7 @@ func (ci *ClassInfo) isGenericClassIndex(fieldType string) bool {
 }
 
 func (ci *ClassInfo) isGenericFieldType(fieldType string) bool {
+	fieldType = strings.TrimPrefix(fieldType, "[]")
 	return strings.Contains(fieldType, "[") && strings.Contains(fieldType, "]")
 }
 
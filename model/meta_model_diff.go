@@ -0,0 +1,338 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// region Model Diff structure -----------------------------------------------------------------------------------------
+
+// Change kinds
+const (
+	ChangeService   = "service"
+	ChangeMethod    = "method"
+	ChangeClass     = "class"
+	ChangeField     = "field"
+	ChangeEnum      = "enum"
+	ChangeEnumValue = "enum value"
+)
+
+// Change actions
+const (
+	ActionAdded      = "added"
+	ActionRemoved    = "removed"
+	ActionChanged    = "changed"
+	ActionDeprecated = "deprecated"
+)
+
+// Change describes single difference between two versions of the model
+type Change struct {
+	Kind     string `json:"kind"`     // Kind of the changed element: service | method | class | field | enum | enum value
+	Action   string `json:"action"`   // What happened to the element: added | removed | changed | deprecated
+	Name     string `json:"name"`     // Full name of the changed element (e.g. UsersService.Get, User.email)
+	Message  string `json:"message"`  // Description of the change
+	Breaking bool   `json:"breaking"` // Is the change breaking clients of the previous version
+}
+
+func (c *Change) String() string {
+	level := "compatible"
+	if c.Breaking {
+		level = "BREAKING"
+	}
+	return fmt.Sprintf("[%s] %s %s: %s", level, c.Kind, c.Name, c.Message)
+}
+
+// Diff compares two versions of the model and returns the list of changes (sorted by kind and name)
+func Diff(prev, curr *MetaModel) []*Change {
+	d := &modelDiff{prev: prev, curr: curr, changes: make([]*Change, 0)}
+	d.diffServices()
+	d.diffClasses()
+	d.diffEnums()
+
+	order := map[string]int{ChangeService: 0, ChangeMethod: 1, ChangeClass: 2, ChangeField: 3, ChangeEnum: 4, ChangeEnumValue: 5}
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		if order[d.changes[i].Kind] != order[d.changes[j].Kind] {
+			return order[d.changes[i].Kind] < order[d.changes[j].Kind]
+		}
+		return d.changes[i].Name < d.changes[j].Name
+	})
+	return d.changes
+}
+
+// HasBreakingChanges checks if any of the changes is breaking
+func HasBreakingChanges(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion
+
+// region Internal diff functions --------------------------------------------------------------------------------------
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*}`)
+
+type modelDiff struct {
+	prev    *MetaModel
+	curr    *MetaModel
+	changes []*Change
+}
+
+func (d *modelDiff) add(kind, action, name string, breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, &Change{Kind: kind, Action: action, Name: name, Message: fmt.Sprintf(format, args...), Breaking: breaking})
+}
+
+func (d *modelDiff) diffServices() {
+	for _, prev := range d.prev.ListServices() {
+		curr := d.curr.GetService(prev.Name)
+		if curr == nil {
+			d.add(ChangeService, ActionRemoved, prev.Name, true, "service removed")
+			continue
+		}
+		d.deprecated(ChangeService, prev.Name, prev.Deprecated, curr.Deprecated)
+
+		methods := make(map[string]*MethodInfo)
+		for _, mi := range curr.Methods {
+			methods[mi.Name] = mi
+		}
+		for _, pm := range prev.Methods {
+			name := fmt.Sprintf("%s.%s", prev.Name, pm.Name)
+			if cm, ok := methods[pm.Name]; !ok {
+				d.add(ChangeMethod, ActionRemoved, name, true, "endpoint %s removed", prev.MethodRoute(pm))
+			} else {
+				d.diffMethod(name, prev, pm, curr, cm)
+			}
+		}
+	}
+
+	for _, curr := range d.curr.ListServices() {
+		prev := d.prev.GetService(curr.Name)
+		if prev == nil {
+			d.add(ChangeService, ActionAdded, curr.Name, false, "service added")
+			continue
+		}
+		methods := make(map[string]bool)
+		for _, mi := range prev.Methods {
+			methods[mi.Name] = true
+		}
+		for _, cm := range curr.Methods {
+			if !methods[cm.Name] {
+				d.add(ChangeMethod, ActionAdded, fmt.Sprintf("%s.%s", curr.Name, cm.Name), false, "endpoint %s added", curr.MethodRoute(cm))
+			}
+		}
+	}
+}
+
+func (d *modelDiff) diffMethod(name string, ps *ServiceInfo, pm *MethodInfo, cs *ServiceInfo, cm *MethodInfo) {
+	d.deprecated(ChangeMethod, name, pm.Deprecated, cm.Deprecated)
+	if !strings.EqualFold(pm.Method, cm.Method) {
+		d.add(ChangeMethod, ActionChanged, name, true, "HTTP verb changed from %s to %s", strings.ToUpper(pm.Method), strings.ToUpper(cm.Method))
+	}
+
+	// Path parameter names are not part of the URL
+	prevPath := pathParamPattern.ReplaceAllString(ps.MethodPath(pm), "{}")
+	currPath := pathParamPattern.ReplaceAllString(cs.MethodPath(cm), "{}")
+	if prevPath != currPath {
+		d.add(ChangeMethod, ActionChanged, name, true, "path changed from %s to %s", ps.MethodPath(pm), cs.MethodPath(cm))
+	}
+
+	// Query parameters
+	query := make(map[string]*ParamInfo)
+	for _, param := range cm.QueryParams {
+		query[param.Json] = param
+	}
+	for _, pp := range pm.QueryParams {
+		if cp, ok := query[pp.Json]; !ok {
+			d.add(ChangeMethod, ActionChanged, name, true, "query parameter %s removed", pp.Json)
+		} else if paramType(pp) != paramType(cp) {
+			d.add(ChangeMethod, ActionChanged, name, true, "query parameter %s type changed from %s to %s", pp.Json, paramType(pp), paramType(cp))
+		}
+		delete(query, pp.Json)
+	}
+	for _, cp := range cm.QueryParams {
+		if _, ok := query[cp.Json]; ok {
+			d.add(ChangeMethod, ActionChanged, name, false, "optional query parameter %s added", cp.Json)
+		}
+	}
+
+	// Path parameters types (names and positions are covered by the path)
+	for i, pp := range pm.PathParams {
+		if i < len(cm.PathParams) && paramType(pp) != paramType(cm.PathParams[i]) {
+			d.add(ChangeMethod, ActionChanged, name, true, "path parameter %s type changed from %s to %s", pp.Json, paramType(pp), paramType(cm.PathParams[i]))
+		}
+	}
+
+	d.diffParam(name, "body", pm.BodyParam, cm.BodyParam)
+	d.diffParam(name, "file", pm.FileParam, cm.FileParam)
+
+	if prevReturn, currReturn := pm.ReturnType.String(), cm.ReturnType.String(); prevReturn != currReturn {
+		d.add(ChangeMethod, ActionChanged, name, true, "return type changed from %s to %s", typeOrNone(prevReturn), typeOrNone(currReturn))
+	}
+}
+
+func (d *modelDiff) diffParam(name, kind string, prev, curr *ParamInfo) {
+	switch {
+	case prev == nil && curr == nil:
+		return
+	case prev == nil:
+		d.add(ChangeMethod, ActionChanged, name, true, "%s parameter %s added", kind, curr.Json)
+	case curr == nil:
+		d.add(ChangeMethod, ActionChanged, name, true, "%s parameter %s removed", kind, prev.Json)
+	case paramType(prev) != paramType(curr):
+		d.add(ChangeMethod, ActionChanged, name, true, "%s parameter type changed from %s to %s", kind, paramType(prev), paramType(curr))
+	}
+}
+
+func (d *modelDiff) diffClasses() {
+	for _, prev := range d.prev.ListClasses() {
+		curr := d.curr.GetClass(prev.Name)
+		if curr == nil {
+			d.add(ChangeClass, ActionRemoved, prev.Name, true, "class removed")
+			continue
+		}
+		d.deprecated(ChangeClass, prev.Name, prev.Deprecated, curr.Deprecated)
+
+		// Fields are matched by their JSON name (inherited fields included)
+		prevFields := d.prev.ListClassFields(prev)
+		currFields := d.curr.ListClassFields(curr)
+		fields := make(map[string]*FieldInfo)
+		for _, fi := range currFields {
+			fields[fi.Json] = fi
+		}
+
+		removed := make([]*FieldInfo, 0)
+		for _, pf := range prevFields {
+			name := fmt.Sprintf("%s.%s", prev.Name, pf.Json)
+			if cf, ok := fields[pf.Json]; !ok {
+				removed = append(removed, pf)
+			} else {
+				if fieldType(pf) != fieldType(cf) {
+					d.add(ChangeField, ActionChanged, name, true, "type changed from %s to %s", fieldType(pf), fieldType(cf))
+				}
+				d.deprecated(ChangeField, name, pf.Deprecated, cf.Deprecated)
+			}
+			delete(fields, pf.Json)
+		}
+
+		// Removed field with the same type as added field is probably renamed
+		for _, pf := range removed {
+			message := "field removed"
+			for _, cf := range currFields {
+				if _, added := fields[cf.Json]; added && fieldType(cf) == fieldType(pf) {
+					message = fmt.Sprintf("field removed (renamed to %s?)", cf.Json)
+					break
+				}
+			}
+			d.add(ChangeField, ActionRemoved, fmt.Sprintf("%s.%s", prev.Name, pf.Json), true, "%s", message)
+		}
+		for _, cf := range currFields {
+			if _, added := fields[cf.Json]; added {
+				d.add(ChangeField, ActionAdded, fmt.Sprintf("%s.%s", curr.Name, cf.Json), false, "field added")
+			}
+		}
+	}
+
+	for _, curr := range d.curr.ListClasses() {
+		if d.prev.GetClass(curr.Name) == nil {
+			d.add(ChangeClass, ActionAdded, curr.Name, false, "class added")
+		}
+	}
+}
+
+func (d *modelDiff) diffEnums() {
+	for _, prev := range d.prev.ListEnums() {
+		curr := d.curr.GetEnum(prev.Name)
+		if curr == nil {
+			d.add(ChangeEnum, ActionRemoved, prev.Name, true, "enum removed")
+			continue
+		}
+		d.deprecated(ChangeEnum, prev.Name, prev.Deprecated, curr.Deprecated)
+
+		// Values are sent by their numeric value: renumbered value is breaking, renamed value is compatible
+		values := make(map[string]*EnumValueInfo)
+		numbers := make(map[int]*EnumValueInfo)
+		for _, ev := range curr.Values {
+			values[ev.Name] = ev
+			numbers[ev.Value] = ev
+		}
+		prevNames := make(map[string]bool)
+		for _, pv := range prev.Values {
+			prevNames[pv.Name] = true
+		}
+
+		for _, pv := range prev.Values {
+			name := fmt.Sprintf("%s.%s", prev.Name, pv.Name)
+			if cv, ok := values[pv.Name]; ok {
+				if cv.Value != pv.Value {
+					d.add(ChangeEnumValue, ActionChanged, name, true, "value renumbered from %d to %d", pv.Value, cv.Value)
+				}
+				d.deprecated(ChangeEnumValue, name, pv.Deprecated, cv.Deprecated)
+			} else if cv, ok := numbers[pv.Value]; ok && !prevNames[cv.Name] {
+				d.add(ChangeEnumValue, ActionChanged, name, false, "value %d renamed to %s", pv.Value, cv.Name)
+			} else {
+				d.add(ChangeEnumValue, ActionRemoved, name, true, "value %d removed", pv.Value)
+			}
+		}
+
+		prevNumbers := make(map[int]bool)
+		for _, pv := range prev.Values {
+			prevNumbers[pv.Value] = true
+		}
+		for _, cv := range curr.Values {
+			if !prevNames[cv.Name] && !prevNumbers[cv.Value] {
+				d.add(ChangeEnumValue, ActionAdded, fmt.Sprintf("%s.%s", curr.Name, cv.Name), false, "value %d added", cv.Value)
+			}
+		}
+	}
+
+	for _, curr := range d.curr.ListEnums() {
+		if d.prev.GetEnum(curr.Name) == nil {
+			d.add(ChangeEnum, ActionAdded, curr.Name, false, "enum added")
+		}
+	}
+}
+
+// Report element which is deprecated in the current version (compatible change)
+func (d *modelDiff) deprecated(kind, name string, prev, curr *DeprecationInfo) {
+	if prev == nil && curr != nil {
+		d.add(kind, ActionDeprecated, name, false, "deprecated: %s", curr)
+	}
+}
+
+// Get the normalized type of parameter
+func paramType(pi *ParamInfo) string {
+	result := NewTypeNode(pi.Type).String()
+	if len(result) == 0 {
+		result = pi.Type
+	}
+	if pi.IsArray && !strings.HasPrefix(result, "[]") {
+		result = "[]" + result
+	}
+	return result
+}
+
+// Get the normalized type of field
+func fieldType(fi *FieldInfo) string {
+	result := NewTypeNodeFromGoType(fi.Type).String()
+	if len(result) == 0 {
+		result = fi.Type
+	}
+	if fi.IsArray && !strings.HasPrefix(result, "[]") {
+		result = "[]" + result
+	}
+	return result
+}
+
+func typeOrNone(name string) string {
+	if len(name) == 0 {
+		return "none"
+	}
+	return name
+}
+
+// endregion
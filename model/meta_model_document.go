@@ -0,0 +1,619 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// region Model Document structure -------------------------------------------------------------------------------------
+
+// ModelFormatVersion is the version of the model interchange format, it is incremented on every incompatible change
+const ModelFormatVersion = 1
+
+// ModelDocument is the serializable form of the meta model (the interchange format of the model in JSON or YAML).
+// Derived information (dependencies, resolved generic types) is not stored, it is recalculated when the model is loaded.
+// Lists are sorted by name, so the same model is always saved to the same document.
+type ModelDocument struct {
+	Version  int                `json:"version" yaml:"version"`   // Format version (ModelFormatVersion)
+	Packages []*PackageDocument `json:"packages" yaml:"packages"` // List of packages
+}
+
+// PackageDocument package in the model document
+type PackageDocument struct {
+	Name     string             `json:"name" yaml:"name"`                             // Package name
+	Docs     []string           `json:"docs,omitempty" yaml:"docs,omitempty"`         // Package documentation
+	Aliases  map[string]string  `json:"aliases,omitempty" yaml:"aliases,omitempty"`   // Map of type aliases to types
+	Enums    []*EnumDocument    `json:"enums,omitempty" yaml:"enums,omitempty"`       // List of enums
+	Classes  []*ClassDocument   `json:"classes,omitempty" yaml:"classes,omitempty"`   // List of classes
+	Services []*ServiceDocument `json:"services,omitempty" yaml:"services,omitempty"` // List of REST services
+	Sockets  []*SocketDocument  `json:"sockets,omitempty" yaml:"sockets,omitempty"`   // List of web sockets
+}
+
+// EnumDocument enum in the model document
+type EnumDocument struct {
+	Name       string               `json:"name" yaml:"name"`                                 // Enum name
+	TsName     string               `json:"tsName,omitempty" yaml:"tsName,omitempty"`         // TypeScript name (when different from the name)
+	Docs       []string             `json:"docs,omitempty" yaml:"docs,omitempty"`             // Enum documentation
+	Type       string               `json:"type,omitempty" yaml:"type,omitempty"`             // Underlying type (e.g. int)
+	Flags      bool                 `json:"flags,omitempty" yaml:"flags,omitempty"`           // Enum values can be combined with bitwise operators
+	Deprecated *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"` // Deprecation details
+	Values     []*EnumValueDocument `json:"values" yaml:"values"`                             // Enum values (in declaration order)
+}
+
+// EnumValueDocument enum value in the model document
+type EnumValueDocument struct {
+	Name       string               `json:"name" yaml:"name"`                                 // Value name
+	Value      int                  `json:"value" yaml:"value"`                               // Numeric value
+	Docs       []string             `json:"docs,omitempty" yaml:"docs,omitempty"`             // Value documentation
+	Deprecated *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"` // Deprecation details
+}
+
+// ClassDocument class in the model document
+type ClassDocument struct {
+	Name       string               `json:"name" yaml:"name"`                                 // Class name
+	TsName     string               `json:"tsName,omitempty" yaml:"tsName,omitempty"`         // TypeScript name (when different from the name)
+	Docs       []string             `json:"docs,omitempty" yaml:"docs,omitempty"`             // Class documentation
+	Kind       string               `json:"kind,omitempty" yaml:"kind,omitempty"`             // Class annotation: @Entity | @Data
+	TableName  string               `json:"tableName,omitempty" yaml:"tableName,omitempty"`   // Database table name (for @Entity)
+	BaseClass  string               `json:"baseClass,omitempty" yaml:"baseClass,omitempty"`   // Base class name
+	Generic    bool                 `json:"generic,omitempty" yaml:"generic,omitempty"`       // Is generic class
+	Generics   []*GenericDocument   `json:"generics,omitempty" yaml:"generics,omitempty"`     // Generic type parameters
+	Param      bool                 `json:"param,omitempty" yaml:"param,omitempty"`           // Is method input / output message
+	Stream     bool                 `json:"stream,omitempty" yaml:"stream,omitempty"`         // Is represented as stream
+	Hidden     bool                 `json:"hidden,omitempty" yaml:"hidden,omitempty"`         // Is hidden from the documentation
+	Deprecated *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"` // Deprecation details
+	Fields     []*FieldDocument     `json:"fields" yaml:"fields"`                             // Class fields (in declaration order)
+}
+
+// GenericDocument generic type parameter in the model document
+type GenericDocument struct {
+	Name string `json:"name" yaml:"name"` // Type parameter name (e.g. T)
+	Type string `json:"type" yaml:"type"` // Type constraint (e.g. any)
+}
+
+// FieldDocument class field in the model document
+type FieldDocument struct {
+	Name       string               `json:"name" yaml:"name"`                                 // Field name
+	Json       string               `json:"json" yaml:"json"`                                 // JSON name
+	Type       string               `json:"type" yaml:"type"`                                 // Field type (Go notation, e.g. Tuple[string, int])
+	TsName     string               `json:"tsName,omitempty" yaml:"tsName,omitempty"`         // TypeScript name (when different from the name)
+	TsType     string               `json:"tsType,omitempty" yaml:"tsType,omitempty"`         // TypeScript type (when set explicitly)
+	Alias      string               `json:"alias,omitempty" yaml:"alias,omitempty"`           // Type alias
+	Format     string               `json:"format,omitempty" yaml:"format,omitempty"`         // Display format hint
+	Array      bool                 `json:"array,omitempty" yaml:"array,omitempty"`           // Is array
+	Map        bool                 `json:"map,omitempty" yaml:"map,omitempty"`               // Is map
+	Complex    bool                 `json:"complex,omitempty" yaml:"complex,omitempty"`       // Is complex type (not number | string | boolean)
+	Generic    bool                 `json:"generic,omitempty" yaml:"generic,omitempty"`       // Is generic type
+	Generics   []*GenericDocument   `json:"generics,omitempty" yaml:"generics,omitempty"`     // Generic type arguments
+	Docs       []string             `json:"docs,omitempty" yaml:"docs,omitempty"`             // Field documentation
+	PrimaryKey bool                 `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"` // Is part of the primary key
+	NotNull    bool                 `json:"notNull,omitempty" yaml:"notNull,omitempty"`       // Is NOT NULL column
+	Indexes    []string             `json:"indexes,omitempty" yaml:"indexes,omitempty"`       // Database indexes names (empty name for single column index)
+	Uniques    []string             `json:"uniques,omitempty" yaml:"uniques,omitempty"`       // Database unique indexes names (empty name for single column index)
+	Deprecated *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"` // Deprecation details
+	Example    string               `json:"example,omitempty" yaml:"example,omitempty"`       // Example value
+}
+
+// ServiceDocument REST service in the model document
+type ServiceDocument struct {
+	Name       string               `json:"name" yaml:"name"`                                 // Service name
+	TsName     string               `json:"tsName,omitempty" yaml:"tsName,omitempty"`         // TypeScript name (when different from the name)
+	Docs       []string             `json:"docs,omitempty" yaml:"docs,omitempty"`             // Service documentation
+	Path       string               `json:"path" yaml:"path"`                                 // Service URI path
+	Group      string               `json:"group,omitempty" yaml:"group,omitempty"`           // Resource group
+	Context    string               `json:"context,omitempty" yaml:"context,omitempty"`       // Context (objects)
+	Headers    []string             `json:"headers,omitempty" yaml:"headers,omitempty"`       // HTTP headers common to all methods
+	Deprecated *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"` // Deprecation details
+	Methods    []*MethodDocument    `json:"methods" yaml:"methods"`                           // Service methods (in declaration order)
+}
+
+// SocketDocument web socket in the model document
+type SocketDocument struct {
+	Name    string            `json:"name" yaml:"name"`                         // Socket name
+	TsName  string            `json:"tsName,omitempty" yaml:"tsName,omitempty"` // TypeScript name (when different from the name)
+	Docs    []string          `json:"docs,omitempty" yaml:"docs,omitempty"`     // Socket documentation
+	Path    string            `json:"path" yaml:"path"`                         // Socket URI path
+	Group   string            `json:"group,omitempty" yaml:"group,omitempty"`   // Socket group
+	Usage   string            `json:"usage,omitempty" yaml:"usage,omitempty"`   // Usage sample
+	Methods []*MethodDocument `json:"methods" yaml:"methods"`                   // Socket messages
+}
+
+// MethodDocument service method in the model document
+type MethodDocument struct {
+	Name        string               `json:"name" yaml:"name"`                                   // Method name
+	TsName      string               `json:"tsName,omitempty" yaml:"tsName,omitempty"`           // TypeScript name (when different from the name)
+	Docs        []string             `json:"docs,omitempty" yaml:"docs,omitempty"`               // Method documentation
+	Method      string               `json:"method" yaml:"method"`                               // HTTP method: GET | POST | PUT | DELETE | PATCH
+	Path        string               `json:"path" yaml:"path"`                                   // Method URI path (relative to the service path)
+	Context     string               `json:"context,omitempty" yaml:"context,omitempty"`         // Context (objects)
+	Headers     []string             `json:"headers,omitempty" yaml:"headers,omitempty"`         // HTTP headers of the method
+	PathParams  []*ParamDocument     `json:"pathParams,omitempty" yaml:"pathParams,omitempty"`   // Path parameters (in path order)
+	QueryParams []*ParamDocument     `json:"queryParams,omitempty" yaml:"queryParams,omitempty"` // Query parameters
+	BodyParam   *ParamDocument       `json:"bodyParam,omitempty" yaml:"bodyParam,omitempty"`     // Body parameter
+	FileParam   *ParamDocument       `json:"fileParam,omitempty" yaml:"fileParam,omitempty"`     // File parameter (multipart upload)
+	Returns     string               `json:"returns,omitempty" yaml:"returns,omitempty"`         // Return type (generic notation, e.g. EntityResponse<User>)
+	Upload      bool                 `json:"upload,omitempty" yaml:"upload,omitempty"`           // Is file upload handler
+	Streams     bool                 `json:"streams,omitempty" yaml:"streams,omitempty"`         // Is request streamed
+	Socket      bool                 `json:"socket,omitempty" yaml:"socket,omitempty"`           // Is socket message
+	MessageType string               `json:"messageType,omitempty" yaml:"messageType,omitempty"` // Socket message type: Request | Response
+	Deprecated  *DeprecationDocument `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`   // Deprecation details
+	Example     string               `json:"example,omitempty" yaml:"example,omitempty"`         // Example of the response
+}
+
+// DeprecationDocument deprecation details in the model document
+type DeprecationDocument struct {
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`           // Why the element is deprecated
+	Since       string `json:"since,omitempty" yaml:"since,omitempty"`             // The version in which the element was deprecated
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"` // The element to use instead
+}
+
+// ParamDocument method parameter in the model document
+type ParamDocument struct {
+	Name    string   `json:"name" yaml:"name"`                           // Parameter name
+	Json    string   `json:"json" yaml:"json"`                           // Parameter name in the request
+	TsName  string   `json:"tsName,omitempty" yaml:"tsName,omitempty"`   // TypeScript name (when different from the JSON name)
+	Type    string   `json:"type" yaml:"type"`                           // Parameter type
+	Array   bool     `json:"array,omitempty" yaml:"array,omitempty"`     // Is array
+	Docs    []string `json:"docs,omitempty" yaml:"docs,omitempty"`       // Parameter documentation
+	Example string   `json:"example,omitempty" yaml:"example,omitempty"` // Example value
+}
+
+// endregion
+
+// region Load and Save ------------------------------------------------------------------------------------------------
+
+// Save the model to file, the format is set by the file extension: .yaml | .yml for YAML, JSON otherwise
+func Save(m *MetaModel, fileName string) error {
+	var (
+		bytes []byte
+		err   error
+	)
+	if isYamlFile(fileName) {
+		bytes, err = yaml.Marshal(m.Document())
+	} else {
+		bytes, err = json.MarshalIndent(m.Document(), "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error writing model [%s]: %s", fileName, err.Error())
+	}
+	return os.WriteFile(fileName, bytes, 0644)
+}
+
+// Load the model from file saved by Save (or produced by other tool), the format is set by the file extension
+func Load(fileName string) (*MetaModel, error) {
+	bytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ModelDocument{}
+	if isYamlFile(fileName) {
+		err = yaml.Unmarshal(bytes, doc)
+	} else {
+		err = json.Unmarshal(bytes, doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading model [%s]: %s", fileName, err.Error())
+	}
+
+	m, err := doc.Model()
+	if err != nil {
+		return nil, fmt.Errorf("error reading model [%s]: %s", fileName, err.Error())
+	}
+	return m, nil
+}
+
+func isYamlFile(fileName string) bool {
+	ext := strings.ToLower(path.Ext(fileName))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// endregion
+
+// region Model to Document --------------------------------------------------------------------------------------------
+
+// Document converts the model to its serializable form
+func (m *MetaModel) Document() *ModelDocument {
+	doc := &ModelDocument{Version: ModelFormatVersion, Packages: make([]*PackageDocument, 0)}
+
+	names := make([]string, 0, len(m.Packages))
+	for name := range m.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pkg := m.Packages[name]
+		pd := &PackageDocument{Name: name, Docs: pkg.Docs}
+		if len(pkg.Aliases) > 0 {
+			pd.Aliases = pkg.Aliases
+		}
+		for _, key := range sortedKeys(pkg.Enums) {
+			pd.Enums = append(pd.Enums, enumDocument(pkg.Enums[key]))
+		}
+		for _, key := range sortedKeys(pkg.Classes) {
+			pd.Classes = append(pd.Classes, classDocument(pkg.Classes[key]))
+		}
+		for _, key := range sortedKeys(pkg.Services) {
+			pd.Services = append(pd.Services, serviceDocument(pkg.Services[key]))
+		}
+		for _, key := range sortedKeys(pkg.Sockets) {
+			pd.Sockets = append(pd.Sockets, socketDocument(pkg.Sockets[key]))
+		}
+		doc.Packages = append(doc.Packages, pd)
+	}
+	return doc
+}
+
+func enumDocument(ei *EnumInfo) *EnumDocument {
+	ed := &EnumDocument{
+		Name:       ei.Name,
+		TsName:     nameIfDifferent(ei.TsName, SmallCaps(ei.Name)),
+		Docs:       ei.Docs,
+		Type:       ei.Type,
+		Flags:      ei.IsFlags,
+		Deprecated: deprecationDocument(ei.Deprecated),
+		Values:     make([]*EnumValueDocument, 0, len(ei.Values)),
+	}
+	for _, ev := range ei.Values {
+		ed.Values = append(ed.Values, &EnumValueDocument{Name: ev.Name, Value: ev.Value, Docs: ev.Docs, Deprecated: deprecationDocument(ev.Deprecated)})
+	}
+	return ed
+}
+
+func classDocument(ci *ClassInfo) *ClassDocument {
+	cd := &ClassDocument{
+		Name:       ci.Name,
+		TsName:     nameIfDifferent(ci.TsName, SmallCaps(ci.Name)),
+		Docs:       ci.Docs,
+		Kind:       ci.Type,
+		TableName:  ci.TableName,
+		BaseClass:  ci.BaseClass,
+		Generic:    ci.IsGeneric,
+		Generics:   genericDocuments(ci.GenericTypes),
+		Param:      ci.IsParam,
+		Stream:     ci.IsStream,
+		Hidden:     !ci.IsVisible,
+		Deprecated: deprecationDocument(ci.Deprecated),
+		Fields:     make([]*FieldDocument, 0, len(ci.Fields)),
+	}
+	for _, fi := range ci.Fields {
+		cd.Fields = append(cd.Fields, &FieldDocument{
+			Name:       fi.Name,
+			Json:       fi.Json,
+			Type:       fi.Type,
+			TsName:     nameIfDifferent(fi.TsName, SmallCaps(fi.Name)),
+			TsType:     fi.TsType,
+			Alias:      fi.Alias,
+			Format:     fi.Format,
+			Array:      fi.IsArray,
+			Map:        fi.IsMap,
+			Complex:    fi.IsComplex,
+			Generic:    fi.IsGeneric,
+			Generics:   genericDocuments(fi.GenericTypes),
+			Docs:       fi.Docs,
+			PrimaryKey: fi.IsPrimaryKey,
+			NotNull:    fi.IsNotNull,
+			Indexes:    fi.Indexes,
+			Uniques:    fi.Uniques,
+			Deprecated: deprecationDocument(fi.Deprecated),
+			Example:    fi.Example,
+		})
+	}
+	return cd
+}
+
+func serviceDocument(si *ServiceInfo) *ServiceDocument {
+	sd := &ServiceDocument{
+		Name:       si.Name,
+		TsName:     nameIfDifferent(si.TsName, SmallCaps(si.Name)),
+		Docs:       si.Docs,
+		Path:       si.Path,
+		Group:      si.Group,
+		Context:    si.Context,
+		Headers:    si.Headers,
+		Deprecated: deprecationDocument(si.Deprecated),
+		Methods:    make([]*MethodDocument, 0, len(si.Methods)),
+	}
+	for _, mi := range si.Methods {
+		sd.Methods = append(sd.Methods, methodDocument(mi))
+	}
+	return sd
+}
+
+func socketDocument(wi *WebSocketInfo) *SocketDocument {
+	sd := &SocketDocument{
+		Name:    wi.Name,
+		TsName:  nameIfDifferent(wi.TsName, SmallCaps(wi.Name)),
+		Docs:    wi.Docs,
+		Path:    wi.Path,
+		Group:   wi.Group,
+		Usage:   wi.Usage,
+		Methods: make([]*MethodDocument, 0, len(wi.Methods)),
+	}
+	for _, mi := range wi.Methods {
+		sd.Methods = append(sd.Methods, methodDocument(mi))
+	}
+	return sd
+}
+
+func methodDocument(mi *MethodInfo) *MethodDocument {
+	md := &MethodDocument{
+		Name:        mi.Name,
+		TsName:      nameIfDifferent(mi.TsName, SmallCaps(mi.Name)),
+		Docs:        mi.Docs,
+		Method:      mi.Method,
+		Path:        mi.Path,
+		Context:     mi.Context,
+		Headers:     mi.Headers,
+		BodyParam:   paramDocument(mi.BodyParam),
+		FileParam:   paramDocument(mi.FileParam),
+		Returns:     mi.ReturnClass,
+		Upload:      mi.IsFileUpload,
+		Streams:     mi.StreamsRequest,
+		Socket:      mi.IsSocketMessage,
+		MessageType: mi.SocketMessageType,
+		Deprecated:  deprecationDocument(mi.Deprecated),
+		Example:     mi.Example,
+	}
+	if mi.ReturnType != nil {
+		md.Returns = mi.ReturnType.String()
+	}
+	for _, pi := range mi.PathParams {
+		md.PathParams = append(md.PathParams, paramDocument(pi))
+	}
+	for _, pi := range mi.QueryParams {
+		md.QueryParams = append(md.QueryParams, paramDocument(pi))
+	}
+	return md
+}
+
+func paramDocument(pi *ParamInfo) *ParamDocument {
+	if pi == nil {
+		return nil
+	}
+	return &ParamDocument{
+		Name:    pi.Name,
+		Json:    pi.Json,
+		TsName:  nameIfDifferent(pi.TsName, pi.Json),
+		Type:    pi.Type,
+		Array:   pi.IsArray,
+		Docs:    pi.Docs,
+		Example: pi.Example,
+	}
+}
+
+func deprecationDocument(di *DeprecationInfo) *DeprecationDocument {
+	if di == nil {
+		return nil
+	}
+	return &DeprecationDocument{Reason: di.Reason, Since: di.Since, Replacement: di.Replacement}
+}
+
+func genericDocuments(list []StringKeyValue) []*GenericDocument {
+	var result []*GenericDocument
+	for _, kv := range list {
+		result = append(result, &GenericDocument{Name: kv.Key, Type: kv.Value})
+	}
+	return result
+}
+
+// Names equal to their default value are not stored
+func nameIfDifferent(name, defaultName string) string {
+	if name == defaultName {
+		return ""
+	}
+	return name
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// endregion
+
+// region Document to Model --------------------------------------------------------------------------------------------
+
+// Model converts the document to meta model and fills the model dependencies
+func (d *ModelDocument) Model() (*MetaModel, error) {
+	if d.Version < 1 || d.Version > ModelFormatVersion {
+		return nil, fmt.Errorf("unsupported model format version: %d (supported versions: 1 - %d)", d.Version, ModelFormatVersion)
+	}
+
+	m := NewMetaModel()
+	for _, pd := range d.Packages {
+		if len(pd.Name) == 0 {
+			return nil, fmt.Errorf("package name is missing")
+		}
+		pkg := m.GetPackage(pd.Name)
+		pkg.Docs = append(pkg.Docs, pd.Docs...)
+		for alias, name := range pd.Aliases {
+			pkg.AddAlias(alias, name)
+		}
+
+		typeInfo := TypeInfo{PackageFullName: pd.Name, PackageShortName: path.Base(pd.Name)}
+		for _, ed := range pd.Enums {
+			pkg.Enums[ed.Name] = ed.enumInfo(typeInfo)
+		}
+		for _, cd := range pd.Classes {
+			pkg.Classes[cd.Name] = cd.classInfo(typeInfo)
+		}
+		for _, sd := range pd.Services {
+			pkg.Services[sd.Name] = sd.serviceInfo(typeInfo)
+		}
+		for _, sd := range pd.Sockets {
+			pkg.Sockets[sd.Name] = sd.socketInfo()
+		}
+	}
+
+	m.FillDependencies()
+	return m, nil
+}
+
+func (d *EnumDocument) enumInfo(ti TypeInfo) *EnumInfo {
+	ei := NewEnumInfo(d.Name, d.Docs...)
+	ei.TsName = nameOrDefault(d.TsName, SmallCaps(d.Name))
+	ei.PackageFullName = ti.PackageFullName
+	ei.PackageShortName = ti.PackageShortName
+	ei.Type = d.Type
+	ei.IsFlags = d.Flags
+	ei.Deprecated = d.Deprecated.deprecationInfo()
+	for _, vd := range d.Values {
+		ev := NewEnumValueInfo(vd.Name, vd.Docs...)
+		ev.Value = vd.Value
+		ev.Deprecated = vd.Deprecated.deprecationInfo()
+		ei.AddValue(ev)
+	}
+	return ei
+}
+
+func (d *ClassDocument) classInfo(ti TypeInfo) *ClassInfo {
+	ci := NewClassInfo(d.Name, d.Docs...)
+	ci.TsName = nameOrDefault(d.TsName, SmallCaps(d.Name))
+	ci.PackageFullName = ti.PackageFullName
+	ci.PackageShortName = ti.PackageShortName
+	ci.Type = d.Kind
+	ci.TableName = d.TableName
+	ci.BaseClass = d.BaseClass
+	ci.IsExtend = len(d.BaseClass) > 0
+	ci.IsGeneric = d.Generic
+	ci.GenericTypes = genericTypes(d.Generics)
+	ci.IsParam = d.Param
+	ci.IsStream = d.Stream
+	ci.IsVisible = !d.Hidden
+	ci.Deprecated = d.Deprecated.deprecationInfo()
+
+	for _, fd := range d.Fields {
+		fi := NewFieldInfo(fd.Name, fd.Docs...)
+		fi.TsName = nameOrDefault(fd.TsName, SmallCaps(fd.Name))
+		fi.Json = nameOrDefault(fd.Json, SmallCaps(fd.Name))
+		fi.Type = fd.Type
+		fi.TsType = fd.TsType
+		fi.Alias = fd.Alias
+		fi.Format = fd.Format
+		fi.IsArray = fd.Array
+		fi.IsMap = fd.Map
+		fi.IsComplex = fd.Complex
+		fi.IsGeneric = fd.Generic
+		fi.GenericTypes = genericTypes(fd.Generics)
+		fi.IsPrimaryKey = fd.PrimaryKey
+		fi.IsNotNull = fd.NotNull
+		fi.Indexes = fd.Indexes
+		fi.Uniques = fd.Uniques
+		fi.Deprecated = fd.Deprecated.deprecationInfo()
+		fi.Example = fd.Example
+		ci.Fields = append(ci.Fields, fi)
+	}
+	return ci
+}
+
+func (d *ServiceDocument) serviceInfo(ti TypeInfo) *ServiceInfo {
+	si := NewServiceInfo(d.Name, d.Docs...)
+	si.TsName = nameOrDefault(d.TsName, SmallCaps(d.Name))
+	si.PackageFullName = ti.PackageFullName
+	si.PackageShortName = ti.PackageShortName
+	si.Type = "@Service"
+	si.Path = d.Path
+	si.Group = d.Group
+	si.Context = d.Context
+	si.Headers = append(si.Headers, d.Headers...)
+	si.Deprecated = d.Deprecated.deprecationInfo()
+	for _, md := range d.Methods {
+		si.Methods = append(si.Methods, md.methodInfo())
+	}
+	return si
+}
+
+func (d *SocketDocument) socketInfo() *WebSocketInfo {
+	wi := NewWebSocketInfo(d.Name)
+	wi.TsName = nameOrDefault(d.TsName, SmallCaps(d.Name))
+	wi.Docs = append(wi.Docs, d.Docs...)
+	wi.Path = d.Path
+	wi.Group = d.Group
+	wi.Usage = d.Usage
+	for _, md := range d.Methods {
+		wi.Methods = append(wi.Methods, md.methodInfo())
+	}
+	return wi
+}
+
+func (d *MethodDocument) methodInfo() *MethodInfo {
+	mi := NewMethodInfo(d.Name)
+	mi.TsName = nameOrDefault(d.TsName, SmallCaps(d.Name))
+	mi.Docs = append(mi.Docs, d.Docs...)
+	mi.Method = d.Method
+	mi.Path = d.Path
+	mi.Context = d.Context
+	mi.Headers = append(mi.Headers, d.Headers...)
+	mi.IsFileUpload = d.Upload
+	mi.StreamsRequest = d.Streams
+	mi.IsSocketMessage = d.Socket
+	mi.SocketMessageType = d.MessageType
+	mi.Deprecated = d.Deprecated.deprecationInfo()
+	mi.Example = d.Example
+
+	for _, pd := range d.PathParams {
+		mi.PathParams = append(mi.PathParams, pd.paramInfo("path"))
+	}
+	for _, pd := range d.QueryParams {
+		mi.QueryParams = append(mi.QueryParams, pd.paramInfo("query"))
+	}
+	if d.BodyParam != nil {
+		mi.BodyParam = d.BodyParam.paramInfo("body")
+	}
+	if d.FileParam != nil {
+		mi.FileParam = d.FileParam.paramInfo("file")
+	}
+	if len(d.Returns) > 0 {
+		mi.Return = NewClassInfo(d.Returns)
+		mi.SetReturnType(d.Returns)
+	}
+	return mi
+}
+
+func (d *ParamDocument) paramInfo(paramType string) *ParamInfo {
+	pi := NewParamInfo(d.Name)
+	pi.Json = nameOrDefault(d.Json, SmallCaps(d.Name))
+	pi.TsName = nameOrDefault(d.TsName, pi.Json)
+	pi.Type = d.Type
+	pi.IsArray = d.Array
+	pi.ParamType = paramType
+	pi.Docs = append(pi.Docs, d.Docs...)
+	pi.Example = d.Example
+	return pi
+}
+
+func (d *DeprecationDocument) deprecationInfo() *DeprecationInfo {
+	if d == nil {
+		return nil
+	}
+	return &DeprecationInfo{Reason: d.Reason, Since: d.Since, Replacement: d.Replacement}
+}
+
+func genericTypes(list []*GenericDocument) []StringKeyValue {
+	result := make([]StringKeyValue, 0, len(list))
+	for _, gd := range list {
+		result = append(result, StringKeyValue{Key: gd.Name, Value: gd.Type})
+	}
+	return result
+}
+
+func nameOrDefault(name, defaultName string) string {
+	if len(name) == 0 {
+		return defaultName
+	}
+	return name
+}
+
+// endregion
@@ -28,9 +28,10 @@ func (e *EnumInfo) AddValue(val *EnumValueInfo) {
 
 // EnumValueInfo enum value information
 type EnumValueInfo struct {
-	Name  string   // Name of value
-	Docs  []string // Documentation
-	Value int      // Numeric value
+	Name       string           // Name of value
+	Docs       []string         // Documentation
+	Value      int              // Numeric value
+	Deprecated *DeprecationInfo // Deprecation details (nil if not deprecated)
 }
 
 func NewEnumValueInfo(name string, doc ...string) *EnumValueInfo {
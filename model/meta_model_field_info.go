@@ -24,6 +24,12 @@ type FieldInfo struct {
 	GenericTypes []StringKeyValue // List of generics name to type
 	Docs         []string         // Field documentation
 	ParamType    string           // How parameter is passed: Query | Path | Body
+	IsPrimaryKey bool             // Is the field part of the entity primary key
+	IsNotNull    bool             // Is the field value required in the database (NOT NULL column)
+	Indexes      []string         // Names of the database indexes the field is part of (empty name for single column index)
+	Uniques      []string         // Names of the database unique indexes the field is part of (empty name for single column index)
+	Deprecated   *DeprecationInfo // Deprecation details (nil if not deprecated)
+	Example      string           // Example value (JSON value, or plain text of string field)
 }
 
 func NewFieldInfo(name string, doc ...string) *FieldInfo {
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -29,6 +31,20 @@ func NewServiceInfo(name string, doc ...string) *ServiceInfo {
 	return si
 }
 
+// MethodPath gets the full path of the service method (service path + method path), the method path / or empty path
+// is the service path itself
+func (s *ServiceInfo) MethodPath(m *MethodInfo) string {
+	if m.Path == "/" || len(m.Path) == 0 {
+		return s.Path
+	}
+	return s.Path + m.Path
+}
+
+// MethodRoute gets the HTTP route of the service method (e.g. GET /users/{id})
+func (s *ServiceInfo) MethodRoute(m *MethodInfo) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(m.Method), s.MethodPath(m))
+}
+
 // Fill the dependencies map
 func (s *ServiceInfo) fillDependencies(mm *MetaModel) {
 
@@ -142,24 +158,26 @@ func replaceTypeNode(node *TypeNode, pi *PackageInfo) {
 
 // MethodInfo service method information
 type MethodInfo struct {
-	Name              string       // Name of the service method
-	TsName            string       // Type Script method name (small caps)
-	Method            string       // HTTP method: GET | POST | PUT | DELETE | PATCH
-	Path              string       // Method URI path
-	Docs              []string     // Documentation
-	Headers           []string     // List of Http headers for this method
-	PathParams        []*ParamInfo // List of service path parameters
-	QueryParams       []*ParamInfo // List of service query parameters
-	BodyParam         *ParamInfo   // Body
-	FileParam         *ParamInfo   // File param (for upload)
-	StreamsRequest    bool         // Is stream
-	Return            *ClassInfo   // Return class info
-	ReturnType        *TypeNode    // Return type node
-	ReturnClass       string       // Return class name
-	Context           string       // Context (objects)
-	IsSocketMessage   bool         // Is this method represents socket message
-	IsFileUpload      bool         // Is this method represents file upload handler
-	SocketMessageType string       // Is method is socket message of type Request | Response
+	Name              string           // Name of the service method
+	TsName            string           // Type Script method name (small caps)
+	Method            string           // HTTP method: GET | POST | PUT | DELETE | PATCH
+	Path              string           // Method URI path
+	Docs              []string         // Documentation
+	Headers           []string         // List of Http headers for this method
+	PathParams        []*ParamInfo     // List of service path parameters
+	QueryParams       []*ParamInfo     // List of service query parameters
+	BodyParam         *ParamInfo       // Body
+	FileParam         *ParamInfo       // File param (for upload)
+	StreamsRequest    bool             // Is stream
+	Return            *ClassInfo       // Return class info
+	ReturnType        *TypeNode        // Return type node
+	ReturnClass       string           // Return class name
+	Context           string           // Context (objects)
+	IsSocketMessage   bool             // Is this method represents socket message
+	IsFileUpload      bool             // Is this method represents file upload handler
+	SocketMessageType string           // Is method is socket message of type Request | Response
+	Deprecated        *DeprecationInfo // Deprecation details (nil if not deprecated)
+	Example           string           // Example of the response (JSON)
 }
 
 func NewMethodInfo(name string) *MethodInfo {
@@ -271,6 +289,34 @@ func (m *MethodInfo) AddFileParam(params string) {
 	m.FileParam = pi
 }
 
+// exampleParamName matches the text before | of parameter example (e.g. id in: id | ord-1001)
+var exampleParamName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetExample decompose example (param | value) of method parameter, example without parameter name is the response
+// example. Returns error when the example names parameter which is not declared (the example is ignored)
+func (m *MethodInfo) SetExample(example string) error {
+	if name, value, ok := strings.Cut(example, "|"); ok {
+		name = strings.TrimSpace(name)
+		for _, pi := range []*ParamInfo{m.FileParam, m.BodyParam} {
+			if pi != nil && (pi.Name == name || pi.Json == name) {
+				pi.Example = strings.TrimSpace(value)
+				return nil
+			}
+		}
+		for _, pi := range append(append(make([]*ParamInfo, 0), m.PathParams...), m.QueryParams...) {
+			if pi.Name == name || pi.Json == name {
+				pi.Example = strings.TrimSpace(value)
+				return nil
+			}
+		}
+		if exampleParamName.MatchString(name) {
+			return fmt.Errorf("example of unknown parameter %s", name)
+		}
+	}
+	m.Example = strings.TrimSpace(example)
+	return nil
+}
+
 // SetUploadFunction decompose upload parameter
 func (m *MethodInfo) SetUploadFunction(name string) {
 	m.Name = name
@@ -329,6 +375,7 @@ type ParamInfo struct {
 	IsArray   bool     // Is it array
 	ParamType string   // How parameter is passed: path | query | body | file
 	Docs      []string // Field documentation
+	Example   string   // Example value (JSON value, or plain text of string parameter)
 }
 
 func NewParamInfo(name string) *ParamInfo {
@@ -355,4 +402,43 @@ func NewTypeNode(input string) *TypeNode {
 	}
 }
 
+// NewTypeNodeFromGoType parses type in Go notation (e.g. []Tuple[string, int]) used by class fields
+func NewTypeNodeFromGoType(input string) *TypeNode {
+	input = strings.TrimSpace(input)
+	isArray := false
+	if strings.HasPrefix(input, "[]") {
+		input = input[2:]
+		isArray = true
+	}
+	input = strings.ReplaceAll(input, "[", "<")
+	input = strings.ReplaceAll(input, "]", ">")
+
+	node := NewTypeNode(input)
+	if node != nil && isArray {
+		node.IsArray = true
+	}
+	return node
+}
+
+// String returns the type in the notation parsed by NewTypeNode (e.g. []EntityResponse<User>), empty string for nil node
+func (t *TypeNode) String() string {
+	if t == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	if t.IsArray {
+		builder.WriteString("[]")
+	}
+	builder.WriteString(t.Name)
+	if len(t.Args) > 0 {
+		args := make([]string, 0, len(t.Args))
+		for _, arg := range t.Args {
+			args = append(args, arg.String())
+		}
+		builder.WriteString("<" + strings.Join(args, ", ") + ">")
+	}
+	return builder.String()
+}
+
 // endregion
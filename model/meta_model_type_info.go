@@ -1,21 +1,27 @@
 package model
 
+import (
+	"fmt"
+	"strings"
+)
+
 // region Type Info structure ------------------------------------------------------------------------------------------
 
 // TypeInfo type information
 type TypeInfo struct {
-	Name             string   // Name of class
-	TsName           string   // TypeScript name (small caps)
-	PackageFullName  string   // Full name of the package
-	PackageShortName string   // Short package name (suffix only)
-	Docs             []string // Class documentation
-	TableName        string   // Name of table (in case it is a persistent entity in database
-	Type             string   // Meta type
-	Alias            string   // Alias to another type name
-	Headers          []string // List of Http headers (common to all service methods)
-	Group            string   // Name of the service group
-	Context          string   // Context (objects)
-	Path             string   // Path of the service
+	Name             string           // Name of class
+	TsName           string           // TypeScript name (small caps)
+	PackageFullName  string           // Full name of the package
+	PackageShortName string           // Short package name (suffix only)
+	Docs             []string         // Class documentation
+	TableName        string           // Name of table (in case it is a persistent entity in database
+	Type             string           // Meta type
+	Alias            string           // Alias to another type name
+	Headers          []string         // List of Http headers (common to all service methods)
+	Group            string           // Name of the service group
+	Context          string           // Context (objects)
+	Path             string           // Path of the service
+	Deprecated       *DeprecationInfo // Deprecation details (nil if not deprecated)
 }
 
 func NewTypeInfo(name string) *TypeInfo {
@@ -35,3 +41,46 @@ func (t *TypeInfo) AddHeader(header string) {
 }
 
 // endregion
+
+// region Deprecation Info structure -----------------------------------------------------------------------------------
+
+// DeprecationInfo deprecation details of service, method, class, field or enum value
+type DeprecationInfo struct {
+	Reason      string // Why the element is deprecated
+	Since       string // The version in which the element was deprecated
+	Replacement string // The element to use instead
+}
+
+// NewDeprecationInfo decompose deprecation annotation value (reason | since | replacement), all parts are optional
+func NewDeprecationInfo(value string) *DeprecationInfo {
+	items := strings.Split(value, "|")
+	di := &DeprecationInfo{Reason: strings.TrimSpace(items[0])}
+	if len(items) > 1 {
+		di.Since = strings.TrimSpace(items[1])
+	}
+	if len(items) > 2 {
+		di.Replacement = strings.TrimSpace(items[2])
+	}
+	return di
+}
+
+// String returns the deprecation message (e.g. "Use the search API; deprecated since 2.3; use Find instead")
+// or "no longer supported" when no details are provided
+func (d *DeprecationInfo) String() string {
+	parts := make([]string, 0)
+	if len(d.Reason) > 0 {
+		parts = append(parts, d.Reason)
+	}
+	if len(d.Since) > 0 {
+		parts = append(parts, "deprecated since "+d.Since)
+	}
+	if len(d.Replacement) > 0 {
+		parts = append(parts, fmt.Sprintf("use %s instead", d.Replacement))
+	}
+	if len(parts) == 0 {
+		return "no longer supported"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// endregion
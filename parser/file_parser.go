@@ -42,6 +42,7 @@ type FileParser struct {
 	ClassMap    map[string]*model.ClassInfo
 	pathFilter  string // Filter to process only files that their path includes the filter
 	parsedFiles map[string]bool
+	Warnings    []string // Annotation problems which do not stop the parsing (e.g. example of unknown parameter)
 }
 
 func NewFileParser(model *model.MetaModel, filter string) *FileParser {
@@ -221,6 +222,8 @@ func (p *FileParser) processTypeComments(ti *model.TypeInfo, groups ...*ast.Comm
 				ti.Context = p.getTagValue(line, "@Context:")
 			} else if strings.HasPrefix(line, "@ResourceGroup") {
 				ti.Group = p.getTagValue(line, "@ResourceGroup:")
+			} else if strings.HasPrefix(line, "@Deprecated") {
+				ti.Deprecated = p.getDeprecation(line)
 			} else {
 				ti.Docs = append(ti.Docs, line)
 			}
@@ -269,4 +272,10 @@ func (p *FileParser) getTagValue(line string, tag string) string {
 	return value
 }
 
+// Extract deprecation details from @Deprecated[: reason | since | replacement] tag
+func (p *FileParser) getDeprecation(line string) *model.DeprecationInfo {
+	value := strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "@Deprecated")), ":")
+	return model.NewDeprecationInfo(value)
+}
+
 // endregion
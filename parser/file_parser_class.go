@@ -29,6 +29,7 @@ func (p *FileParser) processEntityType(ti *model.TypeInfo, decl *ast.GenDecl) er
 	ci.PackageShortName = ti.PackageShortName
 	ci.Docs = ti.Docs
 	ci.TableName = ti.TableName
+	ci.Deprecated = ti.Deprecated
 
 	spec, _ := decl.Specs[0].(*ast.TypeSpec)
 	if spec.TypeParams != nil {
@@ -73,6 +74,7 @@ func (p *FileParser) processDataType(ti *model.TypeInfo, decl *ast.GenDecl) erro
 	ci.PackageShortName = ti.PackageShortName
 	ci.Docs = ti.Docs
 	ci.TableName = ti.TableName
+	ci.Deprecated = ti.Deprecated
 
 	spec, _ := decl.Specs[0].(*ast.TypeSpec)
 	if spec.TypeParams != nil {
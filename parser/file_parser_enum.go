@@ -30,6 +30,7 @@ func (p *FileParser) processEnumType(ti *model.TypeInfo, decl *ast.GenDecl) erro
 	ei.PackageShortName = ti.PackageShortName
 	ei.Docs = ti.Docs
 	ei.TableName = ti.TableName
+	ei.Deprecated = ti.Deprecated
 
 	spec, _ := decl.Specs[0].(*ast.TypeSpec)
 
@@ -85,8 +86,12 @@ func (p *FileParser) processEnumValueComments(evi *model.EnumValueInfo, groups .
 
 	// process comments
 	for _, comment := range list {
-		doc := strings.Replace(comment.Text, "//", "", -1)
-		evi.Docs = append(evi.Docs, strings.TrimSpace(doc))
+		doc := strings.TrimSpace(strings.Replace(comment.Text, "//", "", -1))
+		if strings.HasPrefix(doc, "@Deprecated") {
+			evi.Deprecated = p.getDeprecation(doc)
+		} else {
+			evi.Docs = append(evi.Docs, doc)
+		}
 	}
 	return nil
 }
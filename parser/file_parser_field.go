@@ -87,6 +87,12 @@ func (p *FileParser) processInheritedClass(field *ast.Field, ci *model.ClassInfo
 // Process field comments and extract tags to enrich class and field metadata. The following tags are expected:
 // @InheritFrom: - the field type is the parent class
 // @Json: - the json name of the field
+// @PrimaryKey - the field is part of the entity primary key
+// @NotNull - the field column is not nullable
+// @Index[: name] - the field is indexed (fields with the same index name share multi column index)
+// @Unique[: name] - the field is part of unique index (fields with the same index name share multi column index)
+// @Deprecated[: reason | since | replacement] - the field is deprecated
+// @Example: value - example value of the field (JSON value, or plain text of string field)
 func (p *FileParser) processFieldComments(fi *model.FieldInfo, ci *model.ClassInfo, comments []*ast.Comment) bool {
 
 	for _, comment := range comments {
@@ -105,6 +111,10 @@ func (p *FileParser) processFieldComments(fi *model.FieldInfo, ci *model.ClassIn
 			fi.TsType = p.getTagValue(line, "@Type:")
 		} else if strings.HasPrefix(line, "@Alias:") {
 			fi.Alias = p.getTagValue(line, "@Alias:")
+		} else if strings.HasPrefix(line, "@Deprecated") {
+			fi.Deprecated = p.getDeprecation(line)
+		} else if strings.HasPrefix(line, "@Example:") {
+			fi.Example = p.getTagValue(line, "@Example:")
 		} else if strings.HasPrefix(line, "@Format:") {
 			fi.Format = p.getTagValue(line, "@Format:")
 		} else if strings.HasPrefix(line, "@PathParam") {
@@ -115,6 +125,14 @@ func (p *FileParser) processFieldComments(fi *model.FieldInfo, ci *model.ClassIn
 			fi.ParamType = "body"
 		} else if strings.HasPrefix(line, "@FileParam") {
 			fi.ParamType = "file"
+		} else if strings.HasPrefix(line, "@PrimaryKey") {
+			fi.IsPrimaryKey = true
+		} else if strings.HasPrefix(line, "@NotNull") {
+			fi.IsNotNull = true
+		} else if strings.HasPrefix(line, "@Index") {
+			fi.Indexes = append(fi.Indexes, strings.TrimSpace(strings.TrimPrefix(p.getTagValue(line, "@Index"), ":")))
+		} else if strings.HasPrefix(line, "@Unique") {
+			fi.Uniques = append(fi.Uniques, strings.TrimSpace(strings.TrimPrefix(p.getTagValue(line, "@Unique"), ":")))
 		} else {
 			fi.Docs = append(fi.Docs, line)
 		}
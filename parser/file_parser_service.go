@@ -32,6 +32,7 @@ func (p *FileParser) processServiceType(ti *model.TypeInfo, decl *ast.GenDecl) e
 	si.Context = ti.Context
 	si.Group = ti.Group
 	si.Path = ti.Path
+	si.Deprecated = ti.Deprecated
 
 	// Add class to model
 	p.Model.AddServiceInfo(si)
@@ -88,6 +89,9 @@ func (p *FileParser) processServiceMethodComments(si *model.ServiceInfo, name st
 
 	mi := model.NewMethodInfo(model.Title(name))
 
+	// examples are set after all the parameters are declared
+	examples := make([]string, 0)
+
 	for _, comment := range comments {
 		line := p.trimComment(comment.Text)
 		if len(line) == 0 {
@@ -99,6 +103,10 @@ func (p *FileParser) processServiceMethodComments(si *model.ServiceInfo, name st
 			mi.SetAction(action)
 		} else if strings.HasPrefix(line, "@Context") {
 			mi.Context = p.getTagValue(line, "@Context:")
+		} else if strings.HasPrefix(line, "@Deprecated") {
+			mi.Deprecated = p.getDeprecation(line)
+		} else if strings.HasPrefix(line, "@Example:") {
+			examples = append(examples, p.getTagValue(line, "@Example:"))
 		} else if strings.HasPrefix(line, "@Return:") {
 			returnClass := p.getTagValue(line, "@Return:")
 			mi.Return = model.NewClassInfo(returnClass)
@@ -118,6 +126,11 @@ func (p *FileParser) processServiceMethodComments(si *model.ServiceInfo, name st
 			mi.Docs = append(mi.Docs, line)
 		}
 	}
+	for _, example := range examples {
+		if err := mi.SetExample(example); err != nil {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s.%s: %s", si.Name, mi.Name, err.Error()))
+		}
+	}
 
 	// Add only REST service methods (that Method name is not empty
 	if mi != nil {
@@ -0,0 +1,311 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// exampleMaxDepth is the maximum depth of nested classes in synthesized examples
+const exampleMaxDepth = 5
+
+// exampleTimestamp is the example of timestamp values (2024-01-15T09:30:00Z in milliseconds)
+const exampleTimestamp = "1705311000000"
+
+// exampleId is the example of string identifiers (fields named id or ending with Id)
+const exampleId = "a1b2c3d4"
+
+// exampleRandomEpoch is the start of the range of random timestamps (the example timestamp, one year range)
+const exampleRandomEpoch int64 = 1705311000000
+
+// exampleFormats are the example values of string fields by the field @Format
+var exampleFormats = map[string]string{
+	"email":     "jane.doe@example.com",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"date":      "2024-01-15",
+	"date-time": "2024-01-15T09:30:00Z",
+	"datetime":  "2024-01-15T09:30:00Z",
+	"time":      "09:30:00",
+	"ipv4":      "192.168.1.10",
+	"ipv6":      "2001:db8::1",
+	"hostname":  "api.example.com",
+	"phone":     "+1-202-555-0143",
+	"password":  "********",
+}
+
+// ExampleBuilder synthesizes example JSON documents from the model types, explicit @Example values are used as is.
+// In random mode (used by the mock server) the primitive values are random and the explicit examples are ignored.
+type ExampleBuilder struct {
+	mm       *model.MetaModel
+	random   *rand.Rand
+	visiting map[string]bool // classes in the current path (recursive structures are cut with null)
+}
+
+// NewExampleBuilder - Factory method
+func NewExampleBuilder(mm *model.MetaModel) *ExampleBuilder {
+	return &ExampleBuilder{mm: mm, visiting: make(map[string]bool)}
+}
+
+// WithRandom sets the random source of random mode (the builder is not safe for concurrent use)
+func (b *ExampleBuilder) WithRandom(random *rand.Rand) *ExampleBuilder {
+	b.random = random
+	return b
+}
+
+// Body builds JSON example of the method body parameter (the parameter @Example or synthesized from the body type)
+func (b *ExampleBuilder) Body(param *model.ParamInfo) string {
+	if len(param.Example) > 0 && b.random == nil {
+		return exampleLiteral(param.Example, param.Type)
+	}
+	node := model.NewTypeNode(param.Type)
+	if node == nil {
+		return ""
+	}
+	node.IsArray = node.IsArray || param.IsArray
+	return b.value(node, nil, param.Json, "", "")
+}
+
+// Response builds JSON example of the method response (the method @Example or synthesized from the return type),
+// empty for void and unknown return types
+func (b *ExampleBuilder) Response(mi *model.MethodInfo) string {
+	if len(mi.Example) > 0 && b.random == nil {
+		return exampleLiteral(mi.Example, "")
+	}
+	if mi.ReturnType == nil {
+		return ""
+	}
+	if example := b.value(mi.ReturnType, nil, "", "", ""); example != "null" {
+		return example
+	}
+	return ""
+}
+
+// Param gets the example of path or query parameter as plain text (strings are not quoted, arrays have single item)
+func (b *ExampleBuilder) Param(param *model.ParamInfo) string {
+	value := param.Example
+	if len(value) == 0 || b.random != nil {
+		node := model.NewTypeNode(strings.TrimPrefix(param.Type, "[]"))
+		if node == nil {
+			return ""
+		}
+		value = b.value(node, nil, param.Json, "", "")
+	}
+	var text string
+	if err := json.Unmarshal([]byte(value), &text); err == nil {
+		return text
+	}
+	return value
+}
+
+// build JSON example of the method body parameter
+func sampleBody(mm *model.MetaModel, param *model.ParamInfo) string {
+	return NewExampleBuilder(mm).Body(param)
+}
+
+// build JSON example of the method response
+func sampleResponse(mm *model.MetaModel, mi *model.MethodInfo) string {
+	return NewExampleBuilder(mm).Response(mi)
+}
+
+// get the example of path or query parameter as plain text
+func sampleParam(mm *model.MetaModel, param *model.ParamInfo) string {
+	return NewExampleBuilder(mm).Param(param)
+}
+
+// convert @Example value to JSON, values of string types which are not quoted and invalid JSON values are quoted
+func exampleLiteral(example string, typeName string) string {
+	example = strings.TrimSpace(example)
+	isString := len(typeName) > 0 && !strings.HasPrefix(typeName, "[]") && model.GetTsType(typeName) == "string"
+	if isString && !strings.HasPrefix(example, `"`) {
+		return strconv.Quote(example)
+	}
+	if json.Valid([]byte(example)) {
+		return example
+	}
+	return strconv.Quote(example)
+}
+
+// Build example of type node, generic parameters are replaced by the generics map (e.g. T -> User)
+func (b *ExampleBuilder) value(node *model.TypeNode, generics map[string]*model.TypeNode, name string, format string, indent string) string {
+	if arg, ok := generics[node.Name]; ok {
+		resolved := *arg
+		resolved.IsArray = resolved.IsArray || node.IsArray
+		node = &resolved
+	}
+	if node.IsArray {
+		item := *node
+		item.IsArray = false
+		inner := indent + "  "
+		items := make([]string, 1)
+		if b.random != nil {
+			items = make([]string, 1+b.random.Intn(3))
+		}
+		for i := range items {
+			items[i] = inner + b.value(&item, generics, name, format, inner)
+		}
+		return fmt.Sprintf("[\n%s\n%s]", strings.Join(items, ",\n"), indent)
+	}
+
+	if class := b.mm.GetClass(node.Name); class != nil {
+		return b.class(class, node, generics, indent)
+	}
+	if enum := b.mm.GetEnum(node.Name); enum != nil {
+		if b.random != nil && len(enum.Values) > 0 {
+			return strconv.Itoa(enum.Values[b.random.Intn(len(enum.Values))].Value)
+		}
+		return exampleEnumValue(enum)
+	}
+	if b.random != nil {
+		return b.randomPrimitive(node.Name, name, format)
+	}
+	return examplePrimitive(node.Name, name, format)
+}
+
+// Get random value of primitive type (strings of well known formats are random values of the same format, other
+// strings are the synthesized example with random suffix)
+func (b *ExampleBuilder) randomPrimitive(typeName string, name string, format string) string {
+	r := b.random
+	timestamp := exampleRandomEpoch + r.Int63n(365*24*3600*1000)
+	if typeName == "Timestamp" || (model.GetTsType(typeName) == "number" && (format == "datetime" || format == "date-time")) {
+		return strconv.FormatInt(timestamp, 10)
+	}
+	switch model.GetTsType(typeName) {
+	case "string":
+		text := exampleString(name, format)
+		switch text {
+		case exampleFormats["email"]:
+			text = fmt.Sprintf("user%d@example.com", r.Intn(10000))
+		case exampleFormats["uuid"]:
+			text = fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", r.Uint32(), r.Intn(0x10000), r.Intn(0x1000), 0x8000+r.Intn(0x4000), r.Int63n(1<<48))
+		case exampleFormats["date-time"]:
+			text = time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
+		case exampleId:
+			text = fmt.Sprintf("%08x", r.Uint32())
+		default:
+			if _, ok := exampleFormats[strings.ToLower(format)]; !ok {
+				text = fmt.Sprintf("%s %d", text, 1+r.Intn(1000))
+			}
+		}
+		return strconv.Quote(text)
+	case "number":
+		if strings.HasPrefix(typeName, "float") || typeName == "double" || typeName == "number" {
+			return strconv.FormatFloat(float64(r.Intn(100000))/100, 'f', -1, 64)
+		}
+		return strconv.Itoa(1 + r.Intn(1000))
+	case "boolean":
+		return strconv.FormatBool(r.Intn(2) == 1)
+	}
+	return examplePrimitive(typeName, name, format)
+}
+
+// Build example object of class (base class fields first)
+func (b *ExampleBuilder) class(class *model.ClassInfo, node *model.TypeNode, generics map[string]*model.TypeNode, indent string) string {
+	if b.visiting[class.Name] || len(b.visiting) >= exampleMaxDepth {
+		return "null"
+	}
+	b.visiting[class.Name] = true
+	defer delete(b.visiting, class.Name)
+
+	// map the class generic parameters to the type arguments
+	args := make(map[string]*model.TypeNode)
+	for i, kv := range class.GenericTypes {
+		if i < len(node.Args) {
+			arg := node.Args[i]
+			if resolved, ok := generics[arg.Name]; ok {
+				arg = resolved
+			}
+			args[kv.Key] = arg
+		}
+	}
+
+	inner := indent + "  "
+	lines := make([]string, 0)
+	for _, field := range b.mm.ListClassFields(class) {
+		lines = append(lines, fmt.Sprintf("%s%s: %s", inner, strconv.Quote(field.Json), b.field(field, args, inner)))
+	}
+	if len(lines) == 0 {
+		return "{}"
+	}
+	return fmt.Sprintf("{\n%s\n%s}", strings.Join(lines, ",\n"), indent)
+}
+
+// Build example of class field
+func (b *ExampleBuilder) field(field *model.FieldInfo, generics map[string]*model.TypeNode, indent string) string {
+	if len(field.Example) > 0 && b.random == nil {
+		return exampleLiteral(field.Example, fieldGoType(field))
+	}
+	if field.IsMap {
+		return "{}"
+	}
+	node := model.NewTypeNodeFromGoType(fieldGoType(field))
+	if node == nil {
+		return "null"
+	}
+	return b.value(node, generics, field.Json, field.Format, indent)
+}
+
+// Get the example of enum (the first value which is not zero, zero is usually the undefined value)
+func exampleEnumValue(enum *model.EnumInfo) string {
+	for _, ev := range enum.Values {
+		if ev.Value != 0 {
+			return strconv.Itoa(ev.Value)
+		}
+	}
+	return "0"
+}
+
+// Get the example of primitive type by the format hint and the field name (unknown types are null)
+func examplePrimitive(typeName string, name string, format string) string {
+	if typeName == "Timestamp" {
+		return exampleTimestamp
+	}
+	switch model.GetTsType(typeName) {
+	case "string":
+		return strconv.Quote(exampleString(name, format))
+	case "number":
+		if format == "datetime" || format == "date-time" {
+			return exampleTimestamp
+		}
+		if strings.HasPrefix(typeName, "float") || typeName == "double" || typeName == "number" {
+			return "1.5"
+		}
+		return "1"
+	case "boolean":
+		return "true"
+	case "Record<string,any>", "any":
+		return "{}"
+	case "File":
+		return `""`
+	}
+	return "null"
+}
+
+// Get the example of string by the format hint or the field name (e.g. email, phone, url, id)
+func exampleString(name string, format string) string {
+	if value, ok := exampleFormats[strings.ToLower(format)]; ok {
+		return value
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.Contains(lower, "email"):
+		return exampleFormats["email"]
+	case strings.Contains(lower, "phone") || strings.Contains(lower, "mobile"):
+		return exampleFormats["phone"]
+	case strings.Contains(lower, "url") || strings.Contains(lower, "link"):
+		return exampleFormats["url"]
+	case lower == "id" || strings.HasSuffix(name, "Id"):
+		return exampleId
+	case lower == "name" || strings.HasSuffix(name, "Name"):
+		return "Jane Doe"
+	case len(name) == 0:
+		return "string"
+	}
+	return name
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"unicode"
 )
 
 // Processor interface
@@ -85,6 +86,17 @@ func (p *BaseProcessor) dirCopy(src string, dst string) error {
 	return nil
 }
 
+// Write content to file, create the parent folder if not exists
+func (p *BaseProcessor) writeFile(fileName string, content string) error {
+	if err := os.MkdirAll(path.Dir(fileName), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating folder %s: %s", path.Dir(fileName), err.Error())
+	}
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error writing file %s: %s", fileName, err.Error())
+	}
+	return nil
+}
+
 // remove multiple new lines for better readability
 func (p *BaseProcessor) trimNewLines(source string) string {
 	// Remove newlines
@@ -93,3 +105,177 @@ func (p *BaseProcessor) trimNewLines(source string) string {
 	//result = strings.ReplaceAll(result, "\n\n", "\n")
 	return result
 }
+
+// convert name (camelCase or PascalCase) to snake_case
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Add separator before upper case letter which starts a new word
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+		} else if r == '-' || r == ' ' {
+			builder.WriteRune('_')
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// list method parameters in the order of the generated client method signature: file, path, query and body
+func listMethodParams(mi model.MethodInfo) []*model.ParamInfo {
+	list := make([]*model.ParamInfo, 0)
+	if mi.FileParam != nil {
+		list = append(list, mi.FileParam)
+	}
+	list = append(list, mi.PathParams...)
+	list = append(list, mi.QueryParams...)
+	if mi.BodyParam != nil {
+		list = append(list, mi.BodyParam)
+	}
+	return list
+}
+
+// HasRequestBody checks if the method body parameter is sent with the request (body is not sent with GET and DELETE requests)
+func HasRequestBody(mi *model.MethodInfo) bool {
+	return mi.BodyParam != nil && mi.Method != "GET" && mi.Method != "DELETE"
+}
+
+// add deprecation note to the documentation lines (returns the original lines if not deprecated)
+func deprecatedDocs(docs []string, deprecated *model.DeprecationInfo) []string {
+	if deprecated == nil {
+		return docs
+	}
+	return append(append(make([]string, 0), docs...), fmt.Sprintf("Deprecated: %s", deprecated))
+}
+
+// get the field type in Go notation, array fields are prefixed by [] (e.g. []string)
+func fieldGoType(fi *model.FieldInfo) string {
+	if fi.IsArray && !strings.HasPrefix(fi.Type, "[]") {
+		return "[]" + fi.Type
+	}
+	return fi.Type
+}
+
+// join documentation lines to single line
+func docsLine(docs []string) string {
+	return strings.Join(strings.Fields(joinDocs(docs)), " ")
+}
+
+// append the documentation line to the text (e.g. name - docs)
+func withDocsLine(text string, docs []string) string {
+	if line := docsLine(docs); len(line) > 0 {
+		return fmt.Sprintf("%s - %s", text, line)
+	}
+	return text
+}
+
+// get the deprecation of service method, methods of deprecated service are deprecated as well
+func methodDeprecation(service *model.ServiceInfo, mi *model.MethodInfo) *model.DeprecationInfo {
+	if mi.Deprecated != nil {
+		return mi.Deprecated
+	}
+	return service.Deprecated
+}
+
+// sort classes so every base class is listed before the classes extending it
+func sortClassesByInheritance(mm *model.MetaModel, classes []*model.ClassInfo) []*model.ClassInfo {
+	list := make([]*model.ClassInfo, 0)
+	added := make(map[string]bool)
+
+	var add func(ci *model.ClassInfo)
+	add = func(ci *model.ClassInfo) {
+		if added[ci.Name] {
+			return
+		}
+		added[ci.Name] = true
+		if base := mm.GetClass(ci.BaseClass); base != nil && len(ci.BaseClass) > 0 {
+			add(base)
+		}
+		list = append(list, ci)
+	}
+
+	for _, ci := range classes {
+		add(ci)
+	}
+	return list
+}
+
+// convert enum value name (e.g. USER_ACTIVE) to lower camel case enum case name (e.g. userActive)
+func toEnumCaseName(name string) string {
+	parts := strings.Split(strings.ToLower(name), "_")
+	result := ""
+	for _, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		if len(result) == 0 {
+			result = part
+		} else {
+			result += model.Title(part)
+		}
+	}
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
+
+// Bind generic class type parameters to the type arguments, returns the name of the bound type (e.g. EntityResponseUser)
+func bindGenericTypes(class *model.ClassInfo, args []*model.TypeNode) (string, map[string]*model.TypeNode) {
+	name := class.Name
+	bindings := make(map[string]*model.TypeNode)
+	for i, gt := range class.GenericTypes {
+		var arg *model.TypeNode
+		if i < len(args) {
+			arg = args[i]
+		}
+		bindings[gt.Key] = arg
+		name += genericTypeSuffix(arg)
+	}
+	return name, bindings
+}
+
+// Replace generic type parameters with the bound type arguments (unbound parameters are any)
+func substituteTypeNode(node *model.TypeNode, bindings map[string]*model.TypeNode) *model.TypeNode {
+	if node == nil {
+		return nil
+	}
+	if bound, ok := bindings[node.Name]; ok {
+		if bound == nil {
+			return &model.TypeNode{Name: "any", IsArray: node.IsArray}
+		}
+		if node.IsArray {
+			if bound.IsArray {
+				return &model.TypeNode{Name: "any", IsArray: true}
+			}
+			return &model.TypeNode{Name: bound.Name, Args: bound.Args, IsArray: true}
+		}
+		return bound
+	}
+	result := &model.TypeNode{Name: node.Name, IsArray: node.IsArray}
+	for _, arg := range node.Args {
+		result.Args = append(result.Args, substituteTypeNode(arg, bindings))
+	}
+	return result
+}
+
+// Build type name suffix of generic type argument for languages without generics (e.g. EntityResponse<User> -> EntityResponseUser)
+func genericTypeSuffix(node *model.TypeNode) string {
+	if node == nil {
+		return "Any"
+	}
+	result := model.Title(node.Name)
+	for _, arg := range node.Args {
+		result += genericTypeSuffix(arg)
+	}
+	if node.IsArray {
+		result += "List"
+	}
+	return result
+}
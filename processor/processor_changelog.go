@@ -0,0 +1,381 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// ChangelogProcessor - Changelog processor renders the changes between the previous and the current model as
+// human-readable Markdown (changelog.md) and HTML (changelog.html) release notes
+type ChangelogProcessor struct {
+	BaseProcessor
+	Previous *model.MetaModel
+	Title    string
+}
+
+// NewChangelogProcessor - Factory method
+func NewChangelogProcessor(model *model.MetaModel, output string, previous *model.MetaModel) *ChangelogProcessor {
+	return &ChangelogProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Previous: previous,
+		Title:    "API Changelog",
+	}
+}
+
+// WithTitle sets the changelog title (e.g. the release version)
+func (p *ChangelogProcessor) WithTitle(title string) *ChangelogProcessor {
+	p.Title = title
+	return p
+}
+
+type changelog struct {
+	Title      string
+	Added      int
+	Changed    int
+	Removed    int
+	Deprecated int
+	Breaking   int
+	Groups     []*changelogGroup // Endpoints changes grouped by the service resource group
+	Models     *changelogGroup
+	Enums      *changelogGroup
+}
+
+type changelogGroup struct {
+	Name       string
+	Level      int // Heading level of the group sections
+	Added      []*changelogEntry
+	Changed    []*changelogEntry
+	Removed    []*changelogEntry
+	Deprecated []*changelogEntry
+}
+
+type changelogEntry struct {
+	Title    string
+	Docs     []string
+	Details  []string
+	Breaking bool
+}
+
+// Start the processor
+func (p *ChangelogProcessor) Start() error {
+	data := p.build(model.Diff(p.Previous, p.Model))
+
+	funcMap := template.FuncMap{
+		"docsLine":   docsLine,
+		"hasChanges": func(c *changelog) bool { return c.Added+c.Changed+c.Removed+c.Deprecated > 0 },
+		"isEmpty": func(g *changelogGroup) bool {
+			return len(g.Added)+len(g.Changed)+len(g.Removed)+len(g.Deprecated) == 0
+		},
+		"heading": func(level int) string { return strings.Repeat("#", level) },
+	}
+
+	for fileName, source := range map[string]string{"changelog.md": changelogMarkdownTemplate, "changelog.html": changelogHtmlTemplate} {
+		tmpl, err := template.New(fileName).Funcs(funcMap).Parse(source)
+		if err != nil {
+			return fmt.Errorf("error parsing template [%s]: %s", fileName, err.Error())
+		}
+
+		var tpl bytes.Buffer
+		if err = tmpl.Execute(&tpl, data); err != nil {
+			return fmt.Errorf("error executing template [%s]: %s", fileName, err.Error())
+		}
+		if err = p.writeFile(path.Join(p.Output, fileName), p.trimNewLines(tpl.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build the changelog, changes of the same element are merged into single entry
+func (p *ChangelogProcessor) build(changes []*model.Change) *changelog {
+	result := &changelog{
+		Title:  p.Title,
+		Models: &changelogGroup{Name: "Models", Level: 3},
+		Enums:  &changelogGroup{Name: "Enums", Level: 3},
+	}
+	groups := make(map[string]*changelogGroup)
+	entries := make(map[string]*changelogEntry)
+
+	for _, change := range changes {
+		key := change.Kind + "/" + change.Action + "/" + change.Name
+		if entry, ok := entries[key]; ok {
+			entry.Details = append(entry.Details, change.Message)
+			entry.Breaking = entry.Breaking || change.Breaking
+			continue
+		}
+
+		var group *changelogGroup
+		var entry *changelogEntry
+		switch change.Kind {
+		case model.ChangeService, model.ChangeMethod:
+			var groupName string
+			groupName, entry = p.endpointEntry(change)
+			if group = groups[groupName]; group == nil {
+				group = &changelogGroup{Name: groupName, Level: 4}
+				groups[groupName] = group
+			}
+		case model.ChangeClass, model.ChangeField:
+			group, entry = result.Models, p.modelEntry(change)
+		default:
+			group, entry = result.Enums, p.enumEntry(change)
+		}
+		entry.Breaking = change.Breaking
+		entries[key] = entry
+
+		switch change.Action {
+		case model.ActionAdded:
+			group.Added = append(group.Added, entry)
+			result.Added++
+		case model.ActionRemoved:
+			group.Removed = append(group.Removed, entry)
+			result.Removed++
+		case model.ActionDeprecated:
+			group.Deprecated = append(group.Deprecated, entry)
+			result.Deprecated++
+		default:
+			group.Changed = append(group.Changed, entry)
+			result.Changed++
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Breaking {
+			result.Breaking++
+		}
+	}
+
+	// Services without resource group are listed last
+	for _, group := range groups {
+		result.Groups = append(result.Groups, group)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		if (result.Groups[i].Name == "General") != (result.Groups[j].Name == "General") {
+			return result.Groups[j].Name == "General"
+		}
+		return result.Groups[i].Name < result.Groups[j].Name
+	})
+	return result
+}
+
+// Get the model in which the changed element exists (the previous model for removed elements)
+func (p *ChangelogProcessor) modelOf(change *model.Change) *model.MetaModel {
+	if change.Action == model.ActionRemoved {
+		return p.Previous
+	}
+	return p.Model
+}
+
+// Build entry of service or method change and return its resource group
+func (p *ChangelogProcessor) endpointEntry(change *model.Change) (string, *changelogEntry) {
+	serviceName, methodName, _ := strings.Cut(change.Name, ".")
+	service := p.modelOf(change).GetService(serviceName)
+	if service == nil {
+		return "General", &changelogEntry{Title: change.Name, Details: []string{change.Message}}
+	}
+
+	group := service.Group
+	if len(group) == 0 {
+		group = "General"
+	}
+
+	if change.Kind == model.ChangeService {
+		entry := &changelogEntry{Title: fmt.Sprintf("%s %s", service.Name, service.Path), Docs: service.Docs}
+		if change.Action == model.ActionDeprecated {
+			entry.Details = append(entry.Details, service.Deprecated.String())
+			return group, entry
+		}
+		for _, mi := range service.Methods {
+			entry.Details = append(entry.Details, withDocsLine(service.MethodRoute(mi), mi.Docs))
+		}
+		return group, entry
+	}
+
+	entry := &changelogEntry{Title: change.Name}
+	for _, mi := range service.Methods {
+		if mi.Name == methodName {
+			entry.Title = service.MethodRoute(mi)
+			entry.Docs = mi.Docs
+			if change.Action == model.ActionDeprecated {
+				entry.Details = append(entry.Details, mi.Deprecated.String())
+			}
+		}
+	}
+	if change.Action == model.ActionChanged {
+		entry.Details = append(entry.Details, change.Message)
+	}
+	return group, entry
+}
+
+// Build entry of class or field change
+func (p *ChangelogProcessor) modelEntry(change *model.Change) *changelogEntry {
+	mm := p.modelOf(change)
+	className, fieldJson, _ := strings.Cut(change.Name, ".")
+	class := mm.GetClass(className)
+	if class == nil {
+		return &changelogEntry{Title: change.Name, Details: []string{change.Message}}
+	}
+
+	if change.Kind == model.ChangeClass {
+		entry := &changelogEntry{Title: class.Name, Docs: class.Docs}
+		if change.Action == model.ActionDeprecated {
+			entry.Details = append(entry.Details, class.Deprecated.String())
+		} else if change.Action == model.ActionAdded {
+			for _, fi := range mm.ListClassFields(class) {
+				entry.Details = append(entry.Details, withDocsLine(fmt.Sprintf("%s: %s", fi.Json, fieldGoType(fi)), fi.Docs))
+			}
+		}
+		return entry
+	}
+
+	entry := &changelogEntry{Title: change.Name}
+	for _, fi := range mm.ListClassFields(class) {
+		if fi.Json == fieldJson {
+			entry.Title = fmt.Sprintf("%s: %s", change.Name, fieldGoType(fi))
+			entry.Docs = fi.Docs
+			if change.Action == model.ActionDeprecated {
+				entry.Details = append(entry.Details, fi.Deprecated.String())
+			}
+		}
+	}
+	if change.Action == model.ActionChanged || change.Action == model.ActionRemoved {
+		entry.Details = append(entry.Details, change.Message)
+	}
+	return entry
+}
+
+// Build entry of enum or enum value change
+func (p *ChangelogProcessor) enumEntry(change *model.Change) *changelogEntry {
+	enumName, valueName, _ := strings.Cut(change.Name, ".")
+	enum := p.modelOf(change).GetEnum(enumName)
+	if enum == nil {
+		return &changelogEntry{Title: change.Name, Details: []string{change.Message}}
+	}
+
+	if change.Kind == model.ChangeEnum {
+		entry := &changelogEntry{Title: enum.Name, Docs: enum.Docs}
+		if change.Action == model.ActionDeprecated {
+			entry.Details = append(entry.Details, enum.Deprecated.String())
+		} else if change.Action == model.ActionAdded {
+			for _, ev := range enum.Values {
+				entry.Details = append(entry.Details, withDocsLine(fmt.Sprintf("%s = %d", ev.Name, ev.Value), ev.Docs))
+			}
+		}
+		return entry
+	}
+
+	entry := &changelogEntry{Title: change.Name}
+	for _, ev := range enum.Values {
+		if ev.Name == valueName {
+			entry.Title = fmt.Sprintf("%s = %d", change.Name, ev.Value)
+			entry.Docs = ev.Docs
+			if change.Action == model.ActionDeprecated {
+				entry.Details = append(entry.Details, ev.Deprecated.String())
+			}
+		}
+	}
+	if change.Action == model.ActionChanged {
+		entry.Details = append(entry.Details, change.Message)
+	}
+	return entry
+}
+
+// region Changelog templates ------------------------------------------------------------------------------------------
+
+var changelogMarkdownTemplate = `# {{.Title}}
+
+{{if hasChanges .}}**{{.Added}}** added, **{{.Changed}}** changed, **{{.Removed}}** removed{{if .Deprecated}}, **{{.Deprecated}}** deprecated{{end}}{{if .Breaking}} (**{{.Breaking}} breaking**){{end}}
+{{else}}No API changes.
+{{end}}
+{{if .Groups}}
+## Endpoints
+{{range .Groups}}
+### {{.Name}}
+{{template "section" .}}
+{{end}}
+{{end}}
+{{if not (isEmpty .Models)}}
+## Models
+{{template "section" .Models}}
+{{end}}
+{{if not (isEmpty .Enums)}}
+## Enums
+{{template "section" .Enums}}
+{{end}}
+{{define "section"}}{{$level := heading .Level}}{{with .Added}}
+{{$level}} Added
+{{range .}}{{template "entry" .}}{{end}}{{end}}{{with .Changed}}
+{{$level}} Changed
+{{range .}}{{template "entry" .}}{{end}}{{end}}{{with .Removed}}
+{{$level}} Removed
+{{range .}}{{template "entry" .}}{{end}}{{end}}{{with .Deprecated}}
+{{$level}} Deprecated
+{{range .}}{{template "entry" .}}{{end}}{{end}}{{end}}
+{{define "entry"}}- {{if .Breaking}}**Breaking:** {{end}}` + "`{{.Title}}`" + `{{with docsLine .Docs}} - {{.}}{{end}}
+{{range .Details}}  - {{.}}
+{{end}}{{end}}`
+
+var changelogHtmlTemplate = `<!DOCTYPE html>
+<!-- Code generated by yaaf-code-gen. DO NOT EDIT. -->
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{html .Title}}</title>
+  <style>
+    body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; color: #24292f; }
+    h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+    code { background: #f6f8fa; padding: .1em .4em; border-radius: 4px; }
+    .docs { color: #57606a; }
+    .badge { font-size: .75em; font-weight: 600; padding: .1em .5em; border-radius: 1em; color: #fff; background: #cf222e; }
+    ul.details { margin: .2em 0 .6em; }
+  </style>
+</head>
+<body>
+<h1>{{html .Title}}</h1>
+{{if hasChanges .}}<p><strong>{{.Added}}</strong> added, <strong>{{.Changed}}</strong> changed, <strong>{{.Removed}}</strong> removed{{if .Deprecated}}, <strong>{{.Deprecated}}</strong> deprecated{{end}}{{if .Breaking}} (<strong>{{.Breaking}} breaking</strong>){{end}}</p>
+{{else}}<p>No API changes.</p>
+{{end}}
+{{if .Groups}}
+<h2>Endpoints</h2>
+{{range .Groups}}
+<h3>{{html .Name}}</h3>
+{{template "section" .}}
+{{end}}
+{{end}}
+{{if not (isEmpty .Models)}}
+<h2>Models</h2>
+{{template "section" .Models}}
+{{end}}
+{{if not (isEmpty .Enums)}}
+<h2>Enums</h2>
+{{template "section" .Enums}}
+{{end}}
+</body>
+</html>
+{{define "section"}}{{$level := .Level}}{{with .Added}}
+<h{{$level}}>Added</h{{$level}}>
+<ul>
+{{range .}}{{template "entry" .}}{{end}}</ul>{{end}}{{with .Changed}}
+<h{{$level}}>Changed</h{{$level}}>
+<ul>
+{{range .}}{{template "entry" .}}{{end}}</ul>{{end}}{{with .Removed}}
+<h{{$level}}>Removed</h{{$level}}>
+<ul>
+{{range .}}{{template "entry" .}}{{end}}</ul>{{end}}{{with .Deprecated}}
+<h{{$level}}>Deprecated</h{{$level}}>
+<ul>
+{{range .}}{{template "entry" .}}{{end}}</ul>{{end}}
+{{end}}
+{{define "entry"}}  <li>{{if .Breaking}}<span class="badge">breaking</span> {{end}}<code>{{html .Title}}</code>{{with docsLine .Docs}} <span class="docs">{{html .}}</span>{{end}}{{with .Details}}
+    <ul class="details">{{range .}}<li>{{html .}}</li>{{end}}</ul>{{end}}</li>
+{{end}}`
+
+// endregion
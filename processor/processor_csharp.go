@@ -0,0 +1,573 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var csharpTypes = map[string]string{
+	"double":        "double",
+	"float":         "double",
+	"float32":       "float",
+	"float64":       "double",
+	"int":           "int",
+	"int32":         "int",
+	"int64":         "long",
+	"uint":          "uint",
+	"uint32":        "uint",
+	"uint64":        "ulong",
+	"sint":          "int",
+	"sint32":        "int",
+	"sint64":        "long",
+	"fixed32":       "uint",
+	"fixed64":       "ulong",
+	"sfixed32":      "int",
+	"sfixed64":      "long",
+	"bool":          "bool",
+	"string":        "string",
+	"bytes":         "string",
+	"any":           "JsonElement",
+	"Timestamp":     "long",
+	"Json":          "JsonObject",
+	"StreamContent": "byte[]",
+	"number":        "double",
+	"boolean":       "bool",
+}
+
+var csharpKeywords = map[string]bool{
+	"abstract": true, "as": true, "base": true, "bool": true, "break": true, "byte": true, "case": true,
+	"catch": true, "char": true, "checked": true, "class": true, "const": true, "continue": true,
+	"decimal": true, "default": true, "delegate": true, "do": true, "double": true, "else": true,
+	"enum": true, "event": true, "explicit": true, "extern": true, "false": true, "finally": true,
+	"fixed": true, "float": true, "for": true, "foreach": true, "goto": true, "if": true, "implicit": true,
+	"in": true, "int": true, "interface": true, "internal": true, "is": true, "lock": true, "long": true,
+	"namespace": true, "new": true, "null": true, "object": true, "operator": true, "out": true,
+	"override": true, "params": true, "private": true, "protected": true, "public": true, "readonly": true,
+	"ref": true, "return": true, "sbyte": true, "sealed": true, "short": true, "sizeof": true,
+	"stackalloc": true, "static": true, "string": true, "struct": true, "switch": true, "this": true,
+	"throw": true, "true": true, "try": true, "typeof": true, "uint": true, "ulong": true,
+	"unchecked": true, "unsafe": true, "ushort": true, "using": true, "virtual": true, "void": true,
+	"volatile": true, "while": true,
+}
+
+// CSharpProcessor - C# processor converts the meta model to .NET project (System.Text.Json records and HttpClient based services)
+type CSharpProcessor struct {
+	BaseProcessor
+	Namespace string // Root namespace of the generated project (e.g. Acme.Api)
+}
+
+// NewCSharpProcessor - Factory method
+func NewCSharpProcessor(model *model.MetaModel, output string, namespace string) *CSharpProcessor {
+	return &CSharpProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Namespace: namespace,
+	}
+}
+
+// csharpFile is the template data of a single C# file
+type csharpFile struct {
+	Namespace string
+	Item      any
+}
+
+// Start the processor
+func (p *CSharpProcessor) Start() error {
+
+	funcMap := template.FuncMap{
+		"csDocs":         csDocs,
+		"csMethodDocs":   csMethodDocs,
+		"csObsolete":     csObsolete,
+		"csCaseName":     csCaseName,
+		"csQuote":        strconv.Quote,
+		"csGenerics":     csGenerics,
+		"csBaseClass":    p.csBaseClass,
+		"csPropertyName": csPropertyName,
+		"csFieldType":    p.csFieldType,
+		"csMethodName":   csMethodName,
+		"csMethodParams": p.csMethodParams,
+		"csReturnType":   p.csReturnType,
+		"csMethodBody":   p.csMethodBody,
+		"join":           strings.Join,
+	}
+
+	// Generate all enums
+	for _, enum := range p.Model.ListEnums() {
+		fileName := path.Join(p.Output, "Models", fmt.Sprintf("%s.cs", enum.Name))
+		if err := p.generate(fileName, csharpEnumTemplate, funcMap, enum); err != nil {
+			return err
+		}
+	}
+
+	// Generate all classes
+	for _, class := range p.Model.ListClasses() {
+		if class.IsParam {
+			continue
+		}
+		fileName := path.Join(p.Output, "Models", fmt.Sprintf("%s.cs", class.Name))
+		if err := p.generate(fileName, csharpClassTemplate, funcMap, class); err != nil {
+			return err
+		}
+	}
+
+	// Generate all services
+	for _, service := range p.Model.ListServices() {
+		fileName := path.Join(p.Output, "Services", fmt.Sprintf("%s.cs", service.TsName))
+		if err := p.generate(fileName, csharpServiceTemplate, funcMap, service); err != nil {
+			return err
+		}
+	}
+
+	// Generate project support files
+	if err := p.generate(path.Join(p.Output, "ApiClientBase.cs"), csharpApiClientTemplate, funcMap, nil); err != nil {
+		return err
+	}
+	return p.generate(path.Join(p.Output, fmt.Sprintf("%s.csproj", p.Namespace)), csharpProjectTemplate, funcMap, nil)
+}
+
+// Execute template and write the result to file
+func (p *CSharpProcessor) generate(fileName, source string, funcMap template.FuncMap, item any) error {
+	name := path.Base(fileName)
+	tmpl, err := template.New(name).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", name, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, csharpFile{Namespace: p.Namespace, Item: item}); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", name, err.Error())
+	}
+	return p.writeFile(fileName, p.trimNewLines(tpl.String()))
+}
+
+// Get the base record declaration (only base classes which are part of the model are inherited)
+func (p *CSharpProcessor) csBaseClass(class *model.ClassInfo) string {
+	if len(class.BaseClass) == 0 {
+		return ""
+	}
+	node := model.NewTypeNodeFromGoType(class.BaseClass)
+	if node == nil || p.Model.GetClass(node.Name) == nil {
+		return ""
+	}
+	return " : " + p.csType(node, class.GenericTypes)
+}
+
+// Get the C# type of class field (all fields are optional)
+func (p *CSharpProcessor) csFieldType(class *model.ClassInfo, field *model.FieldInfo) string {
+	csType := p.csType(model.NewTypeNodeFromGoType(field.Type), class.GenericTypes)
+	if field.IsArray {
+		csType = fmt.Sprintf("List<%s>", csType)
+	}
+	return csType + "?"
+}
+
+// Get the C# type of type node
+func (p *CSharpProcessor) csType(node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node == nil {
+		return "JsonElement"
+	}
+
+	result := "JsonElement"
+	if csType, ok := csharpTypes[node.Name]; ok {
+		result = csType
+	} else if p.Model.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := p.Model.GetClass(node.Name); class != nil {
+		result = node.Name
+		if class.IsGeneric && len(class.GenericTypes) > 0 {
+			args := make([]string, 0)
+			for i := range class.GenericTypes {
+				if i < len(node.Args) {
+					args = append(args, p.csType(node.Args[i], generics))
+				} else {
+					args = append(args, "JsonElement")
+				}
+			}
+			result = fmt.Sprintf("%s<%s>", node.Name, strings.Join(args, ", "))
+		}
+	} else if isGenericKey(node.Name, generics) {
+		result = node.Name
+	}
+
+	if node.IsArray {
+		result = fmt.Sprintf("List<%s>", result)
+	}
+	return result
+}
+
+// Get the C# type of method parameter
+func (p *CSharpProcessor) csParamType(param *model.ParamInfo) string {
+	csType := p.csType(model.NewTypeNode(param.Type), nil)
+	if param.IsArray {
+		csType = fmt.Sprintf("IEnumerable<%s>", csType)
+	}
+	return csType
+}
+
+// Build method input parameters list: query parameters are optional, cancellation token is always last
+func (p *CSharpProcessor) csMethodParams(mi *model.MethodInfo) string {
+	required := make([]string, 0)
+	optional := make([]string, 0)
+	for _, param := range listMethodParams(*mi) {
+		name := csParamName(param.Json)
+		switch param.ParamType {
+		case "file":
+			if !mi.IsFileUpload {
+				required = append(required, fmt.Sprintf("byte[] %s", name))
+			}
+		case "query":
+			optional = append(optional, fmt.Sprintf("%s? %s = null", p.csParamType(param), name))
+		case "body":
+			if HasRequestBody(mi) {
+				required = append(required, fmt.Sprintf("%s %s", p.csParamType(param), name))
+			}
+		default:
+			required = append(required, fmt.Sprintf("%s %s", p.csParamType(param), name))
+		}
+	}
+	params := append(required, optional...)
+	if !mi.IsFileUpload {
+		params = append(params, "CancellationToken cancellationToken = default")
+	}
+	return strings.Join(params, ", ")
+}
+
+// Get the method return type
+func (p *CSharpProcessor) csReturnType(mi *model.MethodInfo) string {
+	if mi.IsFileUpload {
+		return "Uri"
+	}
+	if mi.ReturnType == nil {
+		return "async Task"
+	}
+	if mi.ReturnClass == "StreamContent" {
+		return "async Task<byte[]>"
+	}
+	return fmt.Sprintf("async Task<%s>", p.csType(mi.ReturnType, nil))
+}
+
+// Build method content - invoke the http client
+func (p *CSharpProcessor) csMethodBody(mi *model.MethodInfo) string {
+	url := mi.Path
+	if url == "/" {
+		url = ""
+	}
+	literal := strconv.Quote(url)
+	url = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", "{{", "}", "}}").Replace(url)
+	for _, param := range mi.PathParams {
+		url = strings.ReplaceAll(url, fmt.Sprintf("{{%s}}", param.Json), fmt.Sprintf("{Escape(%s)}", csParamName(param.Json)))
+	}
+
+	args := []string{literal}
+	if len(mi.PathParams) > 0 {
+		args[0] = fmt.Sprintf("$\"%s\"", url)
+	}
+	for _, param := range mi.QueryParams {
+		args = append(args, fmt.Sprintf("(%s, Format(%s))", strconv.Quote(param.Json), csParamName(param.Json)))
+	}
+	uri := fmt.Sprintf("BuildUri(%s)", strings.Join(args, ", "))
+
+	// Upload handler returns the upload URL
+	if mi.IsFileUpload {
+		return fmt.Sprintf("return %s;", uri)
+	}
+
+	content := "null"
+	if mi.FileParam != nil {
+		content = fmt.Sprintf("FileContent(%s)", csParamName(mi.FileParam.Json))
+	} else if HasRequestBody(mi) {
+		content = fmt.Sprintf("JsonBody(%s)", csParamName(mi.BodyParam.Json))
+	}
+
+	method := strings.ToUpper(mi.Method[0:1]) + strings.ToLower(mi.Method[1:])
+	lines := []string{fmt.Sprintf("using var response = await SendAsync(HttpMethod.%s, %s, %s, cancellationToken).ConfigureAwait(false);", method, uri, content)}
+	switch {
+	case mi.ReturnType == nil:
+		break
+	case mi.ReturnClass == "StreamContent":
+		lines = append(lines, "return await response.Content.ReadAsByteArrayAsync(cancellationToken).ConfigureAwait(false);")
+	default:
+		lines = append(lines, fmt.Sprintf("return await ReadAsync<%s>(response, cancellationToken).ConfigureAwait(false);", p.csType(mi.ReturnType, nil)))
+	}
+	return strings.Join(lines, "\n        ")
+}
+
+// convert field name to C# property name, property name can't be the same as the enclosing type name
+func csPropertyName(class *model.ClassInfo, field *model.FieldInfo) string {
+	name := strings.ToUpper(field.Name[0:1]) + field.Name[1:]
+	if name == class.Name {
+		name += "Value"
+	}
+	return name
+}
+
+// convert method name to async method name (file upload methods only build the URL)
+func csMethodName(mi *model.MethodInfo) string {
+	name := strings.ToUpper(mi.Name[0:1]) + mi.Name[1:]
+	if mi.IsFileUpload {
+		return name + "Url"
+	}
+	return name + "Async"
+}
+
+// convert name to C# parameter name (escape reserved words)
+func csParamName(name string) string {
+	result := toCamelCase(name)
+	if result == "cancellationToken" {
+		return "cancellationTokenValue"
+	}
+	if csharpKeywords[result] {
+		return "@" + result
+	}
+	return result
+}
+
+// convert enum value name (e.g. USER_ACTIVE) to C# enum member name (e.g. UserActive)
+func csCaseName(name string) string {
+	result := toEnumCaseName(name)
+	return strings.ToUpper(result[0:1]) + result[1:]
+}
+
+// Build generic type parameters list
+func csGenerics(class *model.ClassInfo) string {
+	if !class.IsGeneric || len(class.GenericTypes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0)
+	for _, gt := range class.GenericTypes {
+		keys = append(keys, gt.Key)
+	}
+	return fmt.Sprintf("<%s>", strings.Join(keys, ", "))
+}
+
+// Build XML documentation summary from documentation lines
+func csDocs(docs []string, indent string) string {
+	text := html.EscapeString(joinDocs(docs))
+	if len(text) == 0 {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s/// <summary>%s</summary>\n", indent, text)
+	}
+	output := indent + "/// <summary>\n"
+	for _, line := range lines {
+		output += fmt.Sprintf("%s/// %s\n", indent, line)
+	}
+	return output + indent + "/// </summary>\n"
+}
+
+// Build XML documentation of service method including the parameters
+func csMethodDocs(mi *model.MethodInfo, indent string) string {
+	output := csDocs(mi.Docs, indent)
+	for _, param := range listMethodParams(*mi) {
+		if param.ParamType == "file" && mi.IsFileUpload {
+			continue
+		}
+		if text := html.EscapeString(strings.Join(strings.Fields(joinDocs(param.Docs)), " ")); len(text) > 0 {
+			output += fmt.Sprintf("%s/// <param name=\"%s\">%s</param>\n", indent, strings.TrimPrefix(csParamName(param.Json), "@"), text)
+		}
+	}
+	return output
+}
+
+// Build Obsolete attribute of deprecated element
+func csObsolete(deprecated *model.DeprecationInfo, indent string) string {
+	if deprecated == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s[Obsolete(%s)]\n", indent, strconv.Quote(deprecated.String()))
+}
+
+// region C# templates -------------------------------------------------------------------------------------------------
+
+var csharpProjectTemplate = `<!-- Code generated by yaaf-code-gen. DO NOT EDIT. -->
+<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Nullable>enable</Nullable>
+    <ImplicitUsings>disable</ImplicitUsings>
+    <RootNamespace>{{.Namespace}}</RootNamespace>
+  </PropertyGroup>
+
+</Project>
+`
+
+var csharpEnumTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+using System;
+
+namespace {{.Namespace}}.Models;
+{{with .Item}}
+{{csDocs .Docs ""}}{{csObsolete .Deprecated ""}}public enum {{.Name}}
+{
+{{range .Values}}{{csDocs .Docs "    "}}{{csObsolete .Deprecated "    "}}    {{csCaseName .Name}} = {{.Value}},
+{{end}}}
+{{end}}`
+
+var csharpClassTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+using System;
+using System.Collections.Generic;
+using System.Text.Json;
+using System.Text.Json.Nodes;
+using System.Text.Json.Serialization;
+
+namespace {{.Namespace}}.Models;
+{{with .Item}}{{$class := .}}
+{{csDocs .Docs ""}}{{csObsolete .Deprecated ""}}public record {{.Name}}{{csGenerics .}}{{csBaseClass .}}
+{
+{{range $i, $f := .Fields}}{{if $i}}
+{{end}}{{csDocs $f.Docs "    "}}{{csObsolete $f.Deprecated "    "}}    [JsonPropertyName({{csQuote $f.Json}})]
+    public {{csFieldType $class $f}} {{csPropertyName $class $f}} { get; init; }
+{{end}}}
+{{end}}`
+
+var csharpApiClientTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+using System;
+using System.Collections;
+using System.Globalization;
+using System.Linq;
+using System.Net.Http;
+using System.Text;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+namespace {{.Namespace}};
+
+/// <summary>Error thrown for non successful HTTP status codes</summary>
+public class ApiException : Exception
+{
+    /// <summary>HTTP status code</summary>
+    public int StatusCode { get; }
+
+    /// <summary>Response body</summary>
+    public string Body { get; }
+
+    public ApiException(int statusCode, string body) : base($"HTTP {statusCode}: {body}")
+    {
+        StatusCode = statusCode;
+        Body = body;
+    }
+}
+
+/// <summary>Base class of all the services</summary>
+public abstract class ApiClientBase
+{
+    /// <summary>JSON serialization options (null properties are omitted)</summary>
+    protected static readonly JsonSerializerOptions JsonOptions = new(JsonSerializerDefaults.Web)
+    {
+        DefaultIgnoreCondition = JsonIgnoreCondition.WhenWritingNull,
+    };
+
+    /// <summary>Underlying HTTP client, default request headers are sent with every request</summary>
+    protected HttpClient Client { get; }
+
+    /// <summary>Base URL of the service resource</summary>
+    protected string BaseUrl { get; }
+
+    protected ApiClientBase(HttpClient client, string apiUrl, string path)
+    {
+        Client = client;
+        BaseUrl = apiUrl.TrimEnd('/') + path;
+    }
+
+    /// <summary>Build request URI, null query parameters are omitted</summary>
+    protected Uri BuildUri(string path, params (string Key, string? Value)[] query)
+    {
+        var parts = query
+            .Where(q => q.Value != null)
+            .Select(q => Uri.EscapeDataString(q.Key) + "=" + Uri.EscapeDataString(q.Value!));
+        var queryString = string.Join("&", parts);
+        return new Uri(queryString.Length == 0 ? BaseUrl + path : BaseUrl + path + "?" + queryString);
+    }
+
+    /// <summary>Format parameter value as string (lists are comma separated, enums are numeric)</summary>
+    protected static string? Format(object? value) => value switch
+    {
+        null => null,
+        string s => s,
+        bool b => b ? "true" : "false",
+        Enum e => Convert.ToInt64(e, CultureInfo.InvariantCulture).ToString(CultureInfo.InvariantCulture),
+        IFormattable f => f.ToString(null, CultureInfo.InvariantCulture),
+        IEnumerable list => string.Join(",", list.Cast<object?>().Select(Format)),
+        _ => value.ToString(),
+    };
+
+    /// <summary>Escape path parameter value</summary>
+    protected static string Escape(object? value) => Uri.EscapeDataString(Format(value) ?? string.Empty);
+
+    /// <summary>Serialize request body as JSON</summary>
+    protected static HttpContent JsonBody<T>(T value) =>
+        new StringContent(JsonSerializer.Serialize(value, JsonOptions), Encoding.UTF8, "application/json");
+
+    /// <summary>Build multipart request body with file content</summary>
+    protected static HttpContent FileContent(byte[] file)
+    {
+        var content = new MultipartFormDataContent();
+        content.Add(new ByteArrayContent(file), "fileKey", "file");
+        return content;
+    }
+
+    /// <summary>Send request and verify the response status code</summary>
+    protected async Task<HttpResponseMessage> SendAsync(HttpMethod method, Uri uri, HttpContent? content, CancellationToken cancellationToken)
+    {
+        using var request = new HttpRequestMessage(method, uri) { Content = content };
+        var response = await Client.SendAsync(request, cancellationToken).ConfigureAwait(false);
+        if (!response.IsSuccessStatusCode)
+        {
+            using (response)
+            {
+                var body = await response.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+                throw new ApiException((int)response.StatusCode, body);
+            }
+        }
+        return response;
+    }
+
+    /// <summary>Read JSON response body</summary>
+    protected static async Task<T> ReadAsync<T>(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        var stream = await response.Content.ReadAsStreamAsync(cancellationToken).ConfigureAwait(false);
+        return (await JsonSerializer.DeserializeAsync<T>(stream, JsonOptions, cancellationToken).ConfigureAwait(false))!;
+    }
+}
+`
+
+var csharpServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+using System;
+using System.Collections.Generic;
+using System.Net.Http;
+using System.Text.Json;
+using System.Text.Json.Nodes;
+using System.Threading;
+using System.Threading.Tasks;
+using {{.Namespace}}.Models;
+
+namespace {{.Namespace}}.Services;
+{{with .Item}}
+{{csDocs .Docs ""}}{{with .Headers}}/// <remarks>Expected HTTP headers: {{join . ", "}}</remarks>
+{{end}}{{csObsolete .Deprecated ""}}public class {{.TsName}} : ApiClientBase
+{
+    public {{.TsName}}(HttpClient client, string apiUrl) : base(client, apiUrl, {{csQuote .Path}})
+    {
+    }
+{{range .Methods}}
+{{csMethodDocs . "    "}}{{csObsolete .Deprecated "    "}}    public {{csReturnType .}} {{csMethodName .}}({{csMethodParams .}})
+    {
+        {{csMethodBody .}}
+    }
+{{end}}}
+{{end}}`
+
+// endregion
@@ -0,0 +1,655 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var dartTypes = map[string]string{
+	"double":        "double",
+	"float":         "double",
+	"float32":       "double",
+	"float64":       "double",
+	"int":           "int",
+	"int32":         "int",
+	"int64":         "int",
+	"uint":          "int",
+	"uint32":        "int",
+	"uint64":        "int",
+	"sint":          "int",
+	"sint32":        "int",
+	"sint64":        "int",
+	"fixed32":       "int",
+	"fixed64":       "int",
+	"sfixed32":      "int",
+	"sfixed64":      "int",
+	"bool":          "bool",
+	"string":        "String",
+	"bytes":         "String",
+	"any":           "dynamic",
+	"Timestamp":     "int",
+	"Json":          "Map<String, dynamic>",
+	"StreamContent": "Uint8List",
+	"number":        "double",
+	"boolean":       "bool",
+}
+
+var dartKeywords = map[string]bool{
+	"assert": true, "break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"default": true, "do": true, "else": true, "enum": true, "extends": true, "false": true, "final": true,
+	"finally": true, "for": true, "if": true, "in": true, "is": true, "new": true, "null": true, "rethrow": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"var": true, "void": true, "while": true, "with": true,
+	// Members which are already declared by Object, enums or the service base class
+	"hashCode": true, "runtimeType": true, "toString": true, "noSuchMethod": true, "values": true, "index": true,
+	"toJson": true, "client": true, "headers": true, "baseUrl": true, "send": true, "buildUri": true,
+	"response": true, "uri": true, "request": true,
+}
+
+// DartProcessor - Dart processor converts the meta model to Dart package (models with JSON mapping and http services)
+type DartProcessor struct {
+	BaseProcessor
+	Package string // Dart package name
+}
+
+// NewDartProcessor - Factory method
+func NewDartProcessor(model *model.MetaModel, output string, pkg string) *DartProcessor {
+	return &DartProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Package: pkg,
+	}
+}
+
+// dartFieldInfo is the template data of a single class field
+type dartFieldInfo struct {
+	Name       string
+	Json       string
+	Type       string
+	Docs       []string
+	Deprecated *model.DeprecationInfo
+	Decode     string
+	Encode     string
+}
+
+// Start the processor
+func (p *DartProcessor) Start() error {
+
+	funcMap := template.FuncMap{
+		"dtDocs":         dtDocs,
+		"dtDeprecated":   dtDeprecated,
+		"dtName":         dtName,
+		"dtCaseName":     dtCaseName,
+		"dtFileName":     toSnakeCase,
+		"dtQuote":        dtQuote,
+		"dtGenerics":     dtGenerics,
+		"dtFields":       p.dtFields,
+		"dtEnumValues":   dtEnumValues,
+		"dtMethodParams": p.dtMethodParams,
+		"dtReturnType":   p.dtReturnType,
+		"dtMethodBody":   p.dtMethodBody,
+		"join":           strings.Join,
+		"last":           func(i int, n int) bool { return i == n-1 },
+	}
+
+	lib := path.Join(p.Output, "lib")
+	models := make([]string, 0)
+	services := make([]string, 0)
+
+	// Generate all enums
+	for _, enum := range p.Model.ListEnums() {
+		models = append(models, toSnakeCase(enum.Name))
+		fileName := path.Join(lib, "models", fmt.Sprintf("%s.dart", toSnakeCase(enum.Name)))
+		if err := p.generate(fileName, dartEnumTemplate, funcMap, enum); err != nil {
+			return err
+		}
+	}
+
+	// Generate all classes
+	for _, class := range p.Model.ListClasses() {
+		if class.IsParam {
+			continue
+		}
+		models = append(models, toSnakeCase(class.Name))
+		fileName := path.Join(lib, "models", fmt.Sprintf("%s.dart", toSnakeCase(class.Name)))
+		if err := p.generate(fileName, dartClassTemplate, funcMap, class); err != nil {
+			return err
+		}
+	}
+
+	// Generate all services
+	for _, service := range p.Model.ListServices() {
+		services = append(services, toSnakeCase(service.TsName))
+		fileName := path.Join(lib, "services", fmt.Sprintf("%s.dart", toSnakeCase(service.TsName)))
+		if err := p.generate(fileName, dartServiceTemplate, funcMap, service); err != nil {
+			return err
+		}
+	}
+
+	// Generate package support files
+	if err := p.generate(path.Join(lib, "models", "models.dart"), dartExportsTemplate, funcMap, models); err != nil {
+		return err
+	}
+	if err := p.generate(path.Join(lib, "services", "api_service.dart"), dartApiServiceTemplate, funcMap, nil); err != nil {
+		return err
+	}
+	services = append(services, "api_service")
+	if err := p.generate(path.Join(lib, "services", "services.dart"), dartExportsTemplate, funcMap, services); err != nil {
+		return err
+	}
+	if err := p.generate(path.Join(lib, fmt.Sprintf("%s.dart", p.Package)), dartLibraryTemplate, funcMap, nil); err != nil {
+		return err
+	}
+	return p.generate(path.Join(p.Output, "pubspec.yaml"), dartPubspecTemplate, funcMap, p.Package)
+}
+
+// Execute template and write the result to file
+func (p *DartProcessor) generate(fileName, source string, funcMap template.FuncMap, data any) error {
+	name := path.Base(fileName)
+	tmpl, err := template.New(name).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", name, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", name, err.Error())
+	}
+	return p.writeFile(fileName, p.trimNewLines(tpl.String()))
+}
+
+// List class fields with their JSON mapping expressions (base class fields are flattened)
+func (p *DartProcessor) dtFields(class *model.ClassInfo) []dartFieldInfo {
+	list := make([]dartFieldInfo, 0)
+	for _, field := range p.Model.ListClassFields(class) {
+		node := model.NewTypeNodeFromGoType(field.Type)
+		if node == nil {
+			node = &model.TypeNode{Name: "any"}
+		}
+		if field.IsArray {
+			node = &model.TypeNode{Name: node.Name, Args: node.Args, IsArray: true}
+		}
+		name := dtName(field.Name)
+		fi := dartFieldInfo{
+			Name:       name,
+			Json:       field.Json,
+			Type:       p.dtNullable(p.dtType(node, class.GenericTypes)),
+			Docs:       field.Docs,
+			Deprecated: field.Deprecated,
+		}
+
+		value := fmt.Sprintf("json[%s]", dtQuote(field.Json))
+		fi.Decode = fmt.Sprintf("%s == null ? null : %s", value, p.dtDecode(value, node, class.GenericTypes))
+		if encode := p.dtEncode(name+"!", node, class.GenericTypes); encode == name+"!" {
+			fi.Encode = name
+		} else {
+			fi.Encode = fmt.Sprintf("%s == null ? null : %s", name, encode)
+		}
+		list = append(list, fi)
+	}
+	return list
+}
+
+// Get the Dart type of type node
+func (p *DartProcessor) dtType(node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node == nil {
+		return "dynamic"
+	}
+
+	result := "dynamic"
+	if dtType, ok := dartTypes[node.Name]; ok {
+		result = dtType
+	} else if p.Model.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := p.Model.GetClass(node.Name); class != nil {
+		result = node.Name
+		if class.IsGeneric && len(node.Args) > 0 {
+			args := make([]string, 0)
+			for _, arg := range p.dtGenericArgs(class, node) {
+				args = append(args, p.dtType(arg, generics))
+			}
+			result = fmt.Sprintf("%s<%s>", node.Name, strings.Join(args, ", "))
+		}
+	} else if isGenericKey(node.Name, generics) {
+		result = node.Name
+	}
+
+	if node.IsArray {
+		result = fmt.Sprintf("List<%s>", result)
+	}
+	return result
+}
+
+// Make type nullable (dynamic is nullable by definition)
+func (p *DartProcessor) dtNullable(dtType string) string {
+	if dtType == "dynamic" {
+		return dtType
+	}
+	return dtType + "?"
+}
+
+// Build expression converting JSON value (not null) to the Dart type
+func (p *DartProcessor) dtDecode(value string, node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node.IsArray {
+		elem := &model.TypeNode{Name: node.Name, Args: node.Args}
+		return fmt.Sprintf("(%s as List<dynamic>).map((e) => %s).toList()", value, p.dtDecode("e", elem, generics))
+	}
+
+	switch dtType := dartTypes[node.Name]; dtType {
+	case "int":
+		return fmt.Sprintf("(%s as num).toInt()", value)
+	case "double":
+		return fmt.Sprintf("(%s as num).toDouble()", value)
+	case "bool", "String", "Map<String, dynamic>":
+		return fmt.Sprintf("%s as %s", value, dtType)
+	case "dynamic", "Uint8List":
+		return value
+	}
+
+	if p.Model.GetEnum(node.Name) != nil {
+		return fmt.Sprintf("%s.fromValue((%s as num).toInt())", node.Name, value)
+	}
+	if class := p.Model.GetClass(node.Name); class != nil {
+		if class.IsGeneric && len(class.GenericTypes) > 0 {
+			factories := make([]string, 0)
+			for _, arg := range p.dtGenericArgs(class, node) {
+				factories = append(factories, fmt.Sprintf("(e) => %s", p.dtDecode("e", arg, generics)))
+			}
+			return fmt.Sprintf("%s.fromJson(%s as Map<String, dynamic>, %s)", p.dtType(node, generics), value, strings.Join(factories, ", "))
+		}
+		return fmt.Sprintf("%s.fromJson(%s as Map<String, dynamic>)", node.Name, value)
+	}
+	if isGenericKey(node.Name, generics) {
+		return fmt.Sprintf("fromJson%s(%s)", node.Name, value)
+	}
+	return value
+}
+
+// Build expression converting Dart value (not null) to JSON value
+func (p *DartProcessor) dtEncode(value string, node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node.IsArray {
+		elem := &model.TypeNode{Name: node.Name, Args: node.Args}
+		if encode := p.dtEncode("e", elem, generics); encode != "e" {
+			return fmt.Sprintf("%s.map((e) => %s).toList()", value, encode)
+		}
+		return value
+	}
+	if _, ok := dartTypes[node.Name]; ok {
+		return value
+	}
+	if p.Model.GetEnum(node.Name) != nil {
+		return fmt.Sprintf("%s.value", value)
+	}
+	if class := p.Model.GetClass(node.Name); class != nil {
+		if class.IsGeneric && len(class.GenericTypes) > 0 {
+			factories := make([]string, 0)
+			for _, arg := range p.dtGenericArgs(class, node) {
+				factories = append(factories, fmt.Sprintf("(e) => %s", p.dtEncode("e", arg, generics)))
+			}
+			return fmt.Sprintf("%s.toJson(%s)", value, strings.Join(factories, ", "))
+		}
+		return fmt.Sprintf("%s.toJson()", value)
+	}
+	if isGenericKey(node.Name, generics) {
+		return fmt.Sprintf("toJson%s(%s)", node.Name, value)
+	}
+	return value
+}
+
+// Get the generic arguments of generic class type node (missing arguments are dynamic)
+func (p *DartProcessor) dtGenericArgs(class *model.ClassInfo, node *model.TypeNode) []*model.TypeNode {
+	args := make([]*model.TypeNode, 0)
+	for i := range class.GenericTypes {
+		if i < len(node.Args) {
+			args = append(args, node.Args[i])
+		} else {
+			args = append(args, &model.TypeNode{Name: "any"})
+		}
+	}
+	return args
+}
+
+// Get the Dart type of method parameter
+func (p *DartProcessor) dtParamNode(param *model.ParamInfo) *model.TypeNode {
+	node := model.NewTypeNode(param.Type)
+	if node == nil {
+		node = &model.TypeNode{Name: "any"}
+	}
+	if param.IsArray {
+		node = &model.TypeNode{Name: node.Name, Args: node.Args, IsArray: true}
+	}
+	return node
+}
+
+// Build method input parameters list: path and body parameters are positional, query parameters are named and optional
+func (p *DartProcessor) dtMethodParams(mi *model.MethodInfo) string {
+	positional := make([]string, 0)
+	named := make([]string, 0)
+	for _, param := range listMethodParams(*mi) {
+		name := dtName(param.Json)
+		switch param.ParamType {
+		case "file":
+			if !mi.IsFileUpload {
+				positional = append(positional, fmt.Sprintf("List<int> %s", name))
+			}
+		case "query":
+			named = append(named, fmt.Sprintf("%s %s", p.dtNullable(p.dtType(p.dtParamNode(param), nil)), name))
+		case "body":
+			if HasRequestBody(mi) {
+				positional = append(positional, fmt.Sprintf("%s %s", p.dtType(p.dtParamNode(param), nil), name))
+			}
+		default:
+			positional = append(positional, fmt.Sprintf("%s %s", p.dtType(p.dtParamNode(param), nil), name))
+		}
+	}
+	if len(named) > 0 {
+		positional = append(positional, fmt.Sprintf("{%s}", strings.Join(named, ", ")))
+	}
+	return strings.Join(positional, ", ")
+}
+
+// Get the method return type
+func (p *DartProcessor) dtReturnType(mi *model.MethodInfo) string {
+	if mi.IsFileUpload {
+		return "Uri"
+	}
+	if mi.ReturnType == nil {
+		return "Future<void>"
+	}
+	return fmt.Sprintf("Future<%s>", p.dtType(mi.ReturnType, nil))
+}
+
+// Build method content - invoke the http client
+func (p *DartProcessor) dtMethodBody(mi *model.MethodInfo) string {
+	url := mi.Path
+	if url == "/" {
+		url = ""
+	}
+	url = strings.ReplaceAll(url, "$", `\$`)
+	for _, param := range mi.PathParams {
+		url = strings.ReplaceAll(url, fmt.Sprintf("{%s}", param.Json), fmt.Sprintf("${Uri.encodeComponent(%s.toString())}", dtName(param.Json)))
+	}
+
+	query := make([]string, 0)
+	for _, param := range mi.QueryParams {
+		name := dtName(param.Json)
+		value := name
+		if encode := p.dtQueryValue(name, p.dtParamNode(param)); encode != name {
+			value = fmt.Sprintf("%s == null ? null : %s", name, encode)
+		}
+		query = append(query, fmt.Sprintf("%s: %s", dtQuote(param.Json), value))
+	}
+	uri := fmt.Sprintf("buildUri('%s', {%s})", url, strings.Join(query, ", "))
+
+	// Upload handler returns the upload URL
+	if mi.IsFileUpload {
+		return fmt.Sprintf("return %s;", uri)
+	}
+
+	lines := make([]string, 0)
+	switch {
+	case mi.FileParam != nil:
+		lines = append(lines, fmt.Sprintf("final response = await sendFile('%s', %s, %s);", strings.ToUpper(mi.Method), uri, dtName(mi.FileParam.Json)))
+	case HasRequestBody(mi):
+		body := p.dtEncode(dtName(mi.BodyParam.Json), p.dtParamNode(mi.BodyParam), nil)
+		lines = append(lines, fmt.Sprintf("final response = await send('%s', %s, %s);", strings.ToUpper(mi.Method), uri, body))
+	default:
+		lines = append(lines, fmt.Sprintf("final response = await send('%s', %s);", strings.ToUpper(mi.Method), uri))
+	}
+
+	switch {
+	case mi.ReturnType == nil:
+		break
+	case mi.ReturnClass == "StreamContent":
+		lines = append(lines, "return response.bodyBytes;")
+	default:
+		lines = append(lines, fmt.Sprintf("return %s;", p.dtDecode("jsonDecode(response.body)", mi.ReturnType, nil)))
+	}
+	return strings.Join(lines, "\n    ")
+}
+
+// Build expression converting query parameter value to string
+func (p *DartProcessor) dtQueryValue(value string, node *model.TypeNode) string {
+	if node.IsArray {
+		elem := &model.TypeNode{Name: node.Name, Args: node.Args}
+		return fmt.Sprintf("%s.map((e) => %s).join(',')", value, p.dtQueryValue("e", elem))
+	}
+	if p.Model.GetEnum(node.Name) != nil {
+		return fmt.Sprintf("%s.value", value)
+	}
+	return value
+}
+
+// List enum values, Dart enum must have at least one value
+func dtEnumValues(enum *model.EnumInfo) []*model.EnumValueInfo {
+	if len(enum.Values) == 0 {
+		ev := model.NewEnumValueInfo("UNDEFINED")
+		return []*model.EnumValueInfo{ev}
+	}
+	return enum.Values
+}
+
+// Check if the name is one of the generic type parameters
+func isGenericKey(name string, generics []model.StringKeyValue) bool {
+	for _, gt := range generics {
+		if gt.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// convert name to Dart identifier (escape reserved words)
+func dtName(name string) string {
+	result := toCamelCase(name)
+	if dartKeywords[result] {
+		result += "Value"
+	}
+	return result
+}
+
+// convert enum value name (e.g. USER_ACTIVE) to Dart enum value name (e.g. userActive)
+func dtCaseName(name string) string {
+	result := toEnumCaseName(name)
+	if dartKeywords[result] {
+		result += "Value"
+	}
+	return result
+}
+
+// quote string as Dart single quoted string literal
+func dtQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	s = strings.ReplaceAll(s, `$`, `\$`)
+	return fmt.Sprintf("'%s'", s)
+}
+
+// Build generic type parameters list
+func dtGenerics(class *model.ClassInfo) string {
+	if !class.IsGeneric || len(class.GenericTypes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0)
+	for _, gt := range class.GenericTypes {
+		keys = append(keys, gt.Key)
+	}
+	return fmt.Sprintf("<%s>", strings.Join(keys, ", "))
+}
+
+// Build documentation comment from documentation lines
+func dtDocs(docs []string, indent string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
+	}
+	output := ""
+	for _, line := range strings.Split(text, "\n") {
+		output += fmt.Sprintf("%s/// %s\n", indent, line)
+	}
+	return output
+}
+
+// Build Deprecated annotation of deprecated element
+func dtDeprecated(deprecated *model.DeprecationInfo, indent string) string {
+	if deprecated == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s@Deprecated(%s)\n", indent, dtQuote(deprecated.String()))
+}
+
+// region Dart templates -----------------------------------------------------------------------------------------------
+
+var dartPubspecTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+name: {{.}}
+description: Generated API client library
+version: 1.0.0
+
+environment:
+  sdk: '>=3.0.0 <4.0.0'
+
+dependencies:
+  http: ^1.1.0
+`
+
+var dartLibraryTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+export 'models/models.dart';
+export 'services/services.dart';
+`
+
+var dartExportsTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+{{range .}}export '{{.}}.dart';
+{{end}}`
+
+var dartEnumTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+{{$values := dtEnumValues .}}{{$n := len $values}}
+{{dtDocs .Docs ""}}{{dtDeprecated .Deprecated ""}}enum {{.Name}} {
+{{range $i, $v := $values}}{{dtDocs $v.Docs "  "}}{{dtDeprecated $v.Deprecated "  "}}  {{dtCaseName $v.Name}}({{$v.Value}}){{if last $i $n}};{{else}},{{end}}
+{{end}}
+  const {{.Name}}(this.value);
+
+  /// Numeric value of the enum
+  final int value;
+
+  /// Get enum by its numeric value
+  static {{.Name}} fromValue(int value) => values.firstWhere((e) => e.value == value);
+}
+`
+
+var dartClassTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import 'models.dart';
+{{$fields := dtFields .}}{{$generics := .GenericTypes}}
+{{dtDocs .Docs ""}}{{dtDeprecated .Deprecated ""}}class {{.Name}}{{dtGenerics .}} {
+{{range $fields}}{{dtDocs .Docs "  "}}{{dtDeprecated .Deprecated "  "}}  final {{.Type}} {{.Name}};
+{{end}}
+  const {{.Name}}({{if $fields}}{ {{- range $i, $f := $fields}}{{if $i}}, {{end}}this.{{$f.Name}}{{end -}} }{{end}});
+
+  factory {{.Name}}.fromJson(Map<String, dynamic> json{{range $generics}}, {{.Key}} Function(Object? json) fromJson{{.Key}}{{end}}) => {{.Name}}{{dtGenerics .}}(
+{{range $fields}}        {{.Name}}: {{.Decode}},
+{{end}}      );
+
+  Map<String, dynamic> toJson({{range $i, $g := $generics}}{{if $i}}, {{end}}Object? Function({{$g.Key}} value) toJson{{$g.Key}}{{end}}) => {
+{{range $fields}}        {{dtQuote .Json}}: {{.Encode}},
+{{end}}      };
+}
+`
+
+var dartApiServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import 'dart:convert';
+
+import 'package:http/http.dart' as http;
+
+/// Error thrown for non successful HTTP status codes
+class ApiException implements Exception {
+  final int statusCode;
+  final String body;
+
+  const ApiException(this.statusCode, this.body);
+
+  @override
+  String toString() => 'ApiException($statusCode): $body';
+}
+
+/// Base class of all the services
+abstract class ApiService {
+  /// Base URL of the service resource
+  final String baseUrl;
+
+  /// Underlying HTTP client
+  final http.Client client;
+
+  /// Headers to send with every request
+  final Map<String, String> headers;
+
+  ApiService(String apiUrl, String path, {http.Client? client, Map<String, String>? headers})
+      : baseUrl = (apiUrl.endsWith('/') ? apiUrl.substring(0, apiUrl.length - 1) : apiUrl) + path,
+        client = client ?? http.Client(),
+        headers = headers ?? {};
+
+  /// Build request URI, null query parameters are omitted
+  Uri buildUri(String path, Map<String, Object?> query) {
+    final params = <String, String>{};
+    query.forEach((key, value) {
+      if (value != null) {
+        params[key] = value.toString();
+      }
+    });
+    final uri = Uri.parse(baseUrl + path);
+    return params.isEmpty ? uri : uri.replace(queryParameters: params);
+  }
+
+  /// Send request with optional JSON body
+  Future<http.Response> send(String method, Uri uri, [Object? body]) async {
+    final request = http.Request(method, uri)..headers.addAll(headers);
+    if (body != null) {
+      request.headers['Content-Type'] = 'application/json';
+      request.body = jsonEncode(body);
+    }
+    return _check(await http.Response.fromStream(await client.send(request)));
+  }
+
+  /// Send multipart request with file content
+  Future<http.Response> sendFile(String method, Uri uri, List<int> file) async {
+    final request = http.MultipartRequest(method, uri)
+      ..headers.addAll(headers)
+      ..files.add(http.MultipartFile.fromBytes('fileKey', file, filename: 'file'));
+    return _check(await http.Response.fromStream(await client.send(request)));
+  }
+
+  http.Response _check(http.Response response) {
+    if (response.statusCode < 200 || response.statusCode > 299) {
+      throw ApiException(response.statusCode, response.body);
+    }
+    return response;
+  }
+}
+`
+
+var dartServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:http/http.dart' as http;
+
+import '../models/models.dart';
+import 'api_service.dart';
+
+{{dtDocs .Docs ""}}{{with .Headers}}/// Expected HTTP headers: {{join . ", "}}
+{{end}}{{dtDeprecated .Deprecated ""}}class {{.TsName}} extends ApiService {
+  {{.TsName}}(String apiUrl, {http.Client? client, Map<String, String>? headers})
+      : super(apiUrl, {{dtQuote .Path}}, client: client, headers: headers);
+{{range .Methods}}
+{{dtDocs .Docs "  "}}{{dtDeprecated .Deprecated "  "}}  {{dtReturnType .}} {{dtName .Name}}({{dtMethodParams .}}){{if not .IsFileUpload}} async{{end}} {
+    {{dtMethodBody .}}
+  }
+{{end}}}
+`
+
+// endregion
@@ -0,0 +1,461 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// DiagramProcessor - Diagram processor converts the model classes to class diagrams and the entities (classes with
+// @Entity annotation) to ER diagrams, in PlantUML (.puml) and Mermaid (.mmd and Markdown page) notations
+type DiagramProcessor struct {
+	BaseProcessor
+	Package string // Include only the classes of the package (full or short package name)
+	Group   string // Include only the classes of the resource group and the classes used by the group services
+}
+
+// NewDiagramProcessor - Factory method
+func NewDiagramProcessor(model *model.MetaModel, output string) *DiagramProcessor {
+	return &DiagramProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+// WithPackage includes only the classes of the package (full or short package name) in the diagrams
+func (p *DiagramProcessor) WithPackage(name string) *DiagramProcessor {
+	p.Package = name
+	return p
+}
+
+// WithGroup includes only the classes of the resource group and the classes used by the group services in the diagrams
+func (p *DiagramProcessor) WithGroup(name string) *DiagramProcessor {
+	p.Group = name
+	return p
+}
+
+// diagramPage is the template data of the Markdown diagrams page
+type diagramPage struct {
+	ClassDiagram string
+	ErDiagram    string
+}
+
+// Start the processor
+func (p *DiagramProcessor) Start() error {
+	classes := p.listClasses()
+	entities := listEntities(classes)
+
+	files := map[string]string{
+		"class_diagram.puml": plantUmlClassDiagram(p.Model, classes),
+		"er_diagram.puml":    plantUmlErDiagram(p.Model, entities),
+		"class_diagram.mmd":  mermaidClassDiagram(p.Model, classes),
+		"er_diagram.mmd":     mermaidErDiagram(p.Model, entities),
+	}
+	for _, fileName := range []string{"class_diagram.puml", "er_diagram.puml", "class_diagram.mmd", "er_diagram.mmd"} {
+		if err := p.writeFile(path.Join(p.Output, fileName), files[fileName]); err != nil {
+			return err
+		}
+	}
+
+	tmpl, err := template.New("diagrams.md").Parse(diagramMarkdownTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template [diagrams.md]: %s", err.Error())
+	}
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, diagramPage{ClassDiagram: files["class_diagram.mmd"], ErDiagram: files["er_diagram.mmd"]}); err != nil {
+		return fmt.Errorf("error executing template [diagrams.md]: %s", err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, "diagrams.md"), p.trimNewLines(tpl.String()))
+}
+
+// List the classes matching the package and group filters (sorted by name)
+func (p *DiagramProcessor) listClasses() []*model.ClassInfo {
+	used := make(map[string]bool)
+	if len(p.Group) > 0 {
+		for _, service := range p.Model.ListServices() {
+			if service.Group != p.Group {
+				continue
+			}
+			for _, mi := range service.Methods {
+				for _, param := range listMethodParams(*mi) {
+					p.markUsed(used, model.NewTypeNodeFromGoType(param.Type))
+				}
+				p.markUsed(used, mi.ReturnType)
+			}
+		}
+	}
+
+	list := make([]*model.ClassInfo, 0)
+	for _, class := range p.Model.ListClasses() {
+		if len(p.Package) > 0 && class.PackageFullName != p.Package && class.PackageShortName != p.Package {
+			continue
+		}
+		if len(p.Group) > 0 && class.Group != p.Group && !used[class.Name] {
+			continue
+		}
+		list = append(list, class)
+	}
+	return list
+}
+
+// Mark the classes referred by the type (including the generic type arguments) as used
+func (p *DiagramProcessor) markUsed(used map[string]bool, node *model.TypeNode) {
+	for _, name := range diagramTypeNames(node) {
+		p.markClassUsed(used, name)
+	}
+}
+
+// Mark the class and its dependencies (base class and field types, recursively) as used
+func (p *DiagramProcessor) markClassUsed(used map[string]bool, name string) {
+	class := p.Model.GetClass(name)
+	if class == nil || used[name] {
+		return
+	}
+	used[name] = true
+	for dependency := range class.Dependencies {
+		p.markClassUsed(used, dependency)
+	}
+}
+
+// List the entities (classes with table name)
+func listEntities(classes []*model.ClassInfo) []*model.ClassInfo {
+	list := make([]*model.ClassInfo, 0)
+	for _, class := range classes {
+		if len(class.TableName) > 0 {
+			list = append(list, class)
+		}
+	}
+	return list
+}
+
+// diagramRelation is a relation between two diagram elements
+type diagramRelation struct {
+	From  string
+	To    string
+	Label string
+	Many  bool // The relation refers to many elements (array or map field)
+}
+
+// List the associations of the classes by their field dependencies and the enums used by the fields (sorted by name)
+func diagramAssociations(mm *model.MetaModel, classes []*model.ClassInfo) ([]*diagramRelation, []*model.EnumInfo) {
+	relations := make([]*diagramRelation, 0)
+	enums := make([]*model.EnumInfo, 0)
+	for _, class := range classes {
+		for _, field := range class.Fields {
+			for _, name := range diagramTypeNames(model.NewTypeNodeFromGoType(fieldGoType(field))) {
+				if _, ok := class.Dependencies[name]; !ok {
+					continue
+				}
+				if enum := mm.GetEnum(name); enum != nil && !slices.Contains(enums, enum) {
+					enums = append(enums, enum)
+				}
+				if !diagramContains(classes, name) && mm.GetEnum(name) == nil {
+					continue
+				}
+				relation := &diagramRelation{From: class.Name, To: name, Label: field.Json, Many: field.IsArray || field.IsMap || strings.HasPrefix(field.Type, "[]")}
+				if !slices.ContainsFunc(relations, func(r *diagramRelation) bool { return *r == *relation }) {
+					relations = append(relations, relation)
+				}
+			}
+		}
+	}
+	slices.SortFunc(enums, func(a, b *model.EnumInfo) int { return strings.Compare(a.Name, b.Name) })
+	return relations, enums
+}
+
+// List the relations between the entities: fields of entity type and <entity>Id fields (e.g. userId refers to User)
+func diagramEntityRelations(mm *model.MetaModel, entities []*model.ClassInfo) []*diagramRelation {
+	relations := make([]*diagramRelation, 0)
+	for _, entity := range entities {
+		for _, field := range mm.ListClassFields(entity) {
+			label := sqlNamePart(toSnakeCase(field.Json))
+			for _, name := range diagramTypeNames(model.NewTypeNodeFromGoType(fieldGoType(field))) {
+				if diagramContains(entities, name) {
+					relations = append(relations, &diagramRelation{From: entity.Name, To: name, Label: label, Many: field.IsArray || strings.HasPrefix(field.Type, "[]")})
+				}
+			}
+			if name, ok := strings.CutSuffix(field.Name, "Id"); ok && name != entity.Name && diagramContains(entities, name) {
+				relations = append(relations, &diagramRelation{From: entity.Name, To: name, Label: label})
+			}
+		}
+	}
+	return relations
+}
+
+// List the type names of type node and its generic type arguments (e.g. EntityResponse<User> -> EntityResponse, User)
+func diagramTypeNames(node *model.TypeNode) []string {
+	if node == nil {
+		return nil
+	}
+	names := []string{node.Name}
+	for _, arg := range node.Args {
+		names = append(names, diagramTypeNames(arg)...)
+	}
+	return names
+}
+
+// Check if the list includes class with the name
+func diagramContains(classes []*model.ClassInfo, name string) bool {
+	return slices.ContainsFunc(classes, func(c *model.ClassInfo) bool { return c.Name == name })
+}
+
+// Get the names of the columns referring to other entities
+func diagramForeignKeys(relations []*diagramRelation, entity string) []string {
+	list := make([]string, 0)
+	for _, relation := range relations {
+		if relation.From == entity && !relation.Many {
+			list = append(list, relation.Label)
+		}
+	}
+	return list
+}
+
+// Get the column keys (PK, UK and FK) of entity column
+func diagramColumnKeys(table *sqlTable, column string, foreignKeys []string) []string {
+	keys := make([]string, 0)
+	if slices.Contains(table.PrimaryKey, column) {
+		keys = append(keys, "PK")
+	}
+	for _, idx := range table.Indexes {
+		if idx.Unique && slices.Contains(idx.Columns, column) && !slices.Contains(keys, "UK") {
+			keys = append(keys, "UK")
+		}
+	}
+	if slices.Contains(foreignKeys, column) {
+		keys = append(keys, "FK")
+	}
+	return keys
+}
+
+// Build the field type of the class diagram, generic arguments are wrapped by the open and close strings
+func diagramFieldType(field *model.FieldInfo, open, close string) string {
+	if field.IsMap {
+		return "map"
+	}
+	node := model.NewTypeNodeFromGoType(fieldGoType(field))
+	if node == nil {
+		return field.Type
+	}
+	return diagramTypeNode(node, open, close)
+}
+
+// Build the type node name (arrays are suffixed with [])
+func diagramTypeNode(node *model.TypeNode, open, close string) string {
+	name := node.Name
+	if len(node.Args) > 0 {
+		args := make([]string, 0)
+		for _, arg := range node.Args {
+			args = append(args, diagramTypeNode(arg, open, close))
+		}
+		name += open + strings.Join(args, ",") + close
+	}
+	if node.IsArray {
+		name += "[]"
+	}
+	return name
+}
+
+// Get the generic parameters of class (e.g. T)
+func diagramGenerics(class *model.ClassInfo) string {
+	list := make([]string, 0)
+	for _, kv := range class.GenericTypes {
+		list = append(list, kv.Key)
+	}
+	return strings.Join(list, ",")
+}
+
+// Get the multiplicity of the association target
+func (r *diagramRelation) multiplicity() string {
+	if r.Many {
+		return "*"
+	}
+	return "1"
+}
+
+// region PlantUML diagrams --------------------------------------------------------------------------------------------
+
+// Build PlantUML class diagram of the classes, including the enums used by the class fields
+func plantUmlClassDiagram(mm *model.MetaModel, classes []*model.ClassInfo) string {
+	relations, enums := diagramAssociations(mm, classes)
+
+	var sb strings.Builder
+	sb.WriteString("' Code generated by yaaf-code-gen. DO NOT EDIT.\n@startuml class_diagram\nhide empty members\n")
+	for _, class := range classes {
+		name := class.Name
+		if generics := diagramGenerics(class); len(generics) > 0 {
+			name += "<" + generics + ">"
+		}
+		stereotype := ""
+		if len(class.TableName) > 0 {
+			stereotype = " <<Entity>>"
+		}
+		fmt.Fprintf(&sb, "\nclass %s%s {\n", name, stereotype)
+		for _, field := range class.Fields {
+			fmt.Fprintf(&sb, "  +%s : %s\n", field.Json, diagramFieldType(field, "<", ">"))
+		}
+		sb.WriteString("}\n")
+		if class.Deprecated != nil {
+			fmt.Fprintf(&sb, "note top of %s : deprecated\n", class.Name)
+		}
+	}
+	for _, enum := range enums {
+		fmt.Fprintf(&sb, "\nenum %s {\n", enum.Name)
+		for _, value := range enum.Values {
+			fmt.Fprintf(&sb, "  %s\n", value.Name)
+		}
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString("\n")
+	for _, class := range classes {
+		if diagramContains(classes, class.BaseClass) {
+			fmt.Fprintf(&sb, "%s <|-- %s\n", class.BaseClass, class.Name)
+		}
+	}
+	for _, r := range relations {
+		fmt.Fprintf(&sb, "%s --> \"%s\" %s : %s\n", r.From, r.multiplicity(), r.To, r.Label)
+	}
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
+
+// Build PlantUML ER diagram (information engineering notation) of the entities
+func plantUmlErDiagram(mm *model.MetaModel, entities []*model.ClassInfo) string {
+	relations := diagramEntityRelations(mm, entities)
+
+	var sb strings.Builder
+	sb.WriteString("' Code generated by yaaf-code-gen. DO NOT EDIT.\n@startuml er_diagram\nhide circle\nskinparam linetype ortho\n")
+	for _, entity := range entities {
+		table := buildSqlTable(mm, entity)
+		foreignKeys := diagramForeignKeys(relations, entity.Name)
+		fmt.Fprintf(&sb, "\nentity \"%s\" as %s {\n", table.Name, entity.Name)
+		for _, column := range table.Columns {
+			if slices.Contains(table.PrimaryKey, column.Name) {
+				fmt.Fprintf(&sb, "  * %s : %s\n", column.Name, column.Type)
+			}
+		}
+		sb.WriteString("  --\n")
+		for _, column := range table.Columns {
+			if slices.Contains(table.PrimaryKey, column.Name) {
+				continue
+			}
+			required := ""
+			if column.NotNull {
+				required = "* "
+			}
+			keys := diagramColumnKeys(table, column.Name, foreignKeys)
+			suffix := ""
+			if len(keys) > 0 {
+				suffix = " <<" + strings.Join(keys, ",") + ">>"
+			}
+			fmt.Fprintf(&sb, "  %s%s : %s%s\n", required, column.Name, column.Type, suffix)
+		}
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString("\n")
+	for _, r := range relations {
+		if r.Many {
+			fmt.Fprintf(&sb, "%s ||--o{ %s : %s\n", r.From, r.To, r.Label)
+		} else {
+			fmt.Fprintf(&sb, "%s }o--|| %s : %s\n", r.From, r.To, r.Label)
+		}
+	}
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
+
+// endregion
+
+// region Mermaid diagrams ---------------------------------------------------------------------------------------------
+
+// Build Mermaid class diagram of the classes, including the enums used by the class fields
+func mermaidClassDiagram(mm *model.MetaModel, classes []*model.ClassInfo) string {
+	relations, enums := diagramAssociations(mm, classes)
+
+	var sb strings.Builder
+	sb.WriteString("classDiagram\n")
+	for _, class := range classes {
+		name := class.Name
+		if generics := diagramGenerics(class); len(generics) > 0 {
+			name += "~" + generics + "~"
+		}
+		fmt.Fprintf(&sb, "  class %s {\n", name)
+		if len(class.TableName) > 0 {
+			sb.WriteString("    <<Entity>>\n")
+		}
+		for _, field := range class.Fields {
+			fmt.Fprintf(&sb, "    +%s %s\n", diagramFieldType(field, "~", "~"), field.Json)
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, enum := range enums {
+		fmt.Fprintf(&sb, "  class %s {\n    <<enumeration>>\n", enum.Name)
+		for _, value := range enum.Values {
+			fmt.Fprintf(&sb, "    %s\n", value.Name)
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, class := range classes {
+		if diagramContains(classes, class.BaseClass) {
+			fmt.Fprintf(&sb, "  %s <|-- %s\n", class.BaseClass, class.Name)
+		}
+	}
+	for _, r := range relations {
+		fmt.Fprintf(&sb, "  %s --> \"%s\" %s : %s\n", r.From, r.multiplicity(), r.To, r.Label)
+	}
+	return sb.String()
+}
+
+// Build Mermaid ER diagram of the entities
+func mermaidErDiagram(mm *model.MetaModel, entities []*model.ClassInfo) string {
+	relations := diagramEntityRelations(mm, entities)
+
+	var sb strings.Builder
+	sb.WriteString("erDiagram\n")
+	for _, entity := range entities {
+		table := buildSqlTable(mm, entity)
+		foreignKeys := diagramForeignKeys(relations, entity.Name)
+		fmt.Fprintf(&sb, "  %s[\"%s\"] {\n", entity.Name, table.Name)
+		for _, column := range table.Columns {
+			line := fmt.Sprintf("    %s %s", strings.ReplaceAll(column.Type, " ", "_"), column.Name)
+			if keys := diagramColumnKeys(table, column.Name, foreignKeys); len(keys) > 0 {
+				line += " " + strings.Join(keys, ", ")
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, r := range relations {
+		if r.Many {
+			fmt.Fprintf(&sb, "  %s ||--o{ %s : \"%s\"\n", r.From, r.To, r.Label)
+		} else {
+			fmt.Fprintf(&sb, "  %s }o--|| %s : \"%s\"\n", r.From, r.To, r.Label)
+		}
+	}
+	return sb.String()
+}
+
+// endregion
+
+// region Diagram templates --------------------------------------------------------------------------------------------
+
+var diagramMarkdownTemplate = `# Data Model
+
+## Class Diagram
+
+` + "```mermaid\n{{.ClassDiagram}}```" + `
+
+## ER Diagram
+
+` + "```mermaid\n{{.ErDiagram}}```" + `
+`
+
+// endregion
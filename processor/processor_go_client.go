@@ -0,0 +1,573 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var goTypes = map[string]string{
+	"double":        "float64",
+	"float":         "float32",
+	"float32":       "float32",
+	"float64":       "float64",
+	"int":           "int",
+	"int32":         "int32",
+	"int64":         "int64",
+	"uint":          "uint",
+	"uint32":        "uint32",
+	"uint64":        "uint64",
+	"sint":          "int",
+	"sint32":        "int32",
+	"sint64":        "int64",
+	"fixed32":       "uint32",
+	"fixed64":       "uint64",
+	"sfixed32":      "int32",
+	"sfixed64":      "int64",
+	"bool":          "bool",
+	"string":        "string",
+	"bytes":         "[]byte",
+	"any":           "any",
+	"Timestamp":     "int64",
+	"Json":          "map[string]any",
+	"StreamContent": "[]byte",
+	"number":        "float64",
+	"boolean":       "bool",
+}
+
+// GoClientProcessor - Go client processor converts the services to typed Go client package using the original Go types
+type GoClientProcessor struct {
+	BaseProcessor
+	Package     string            // Generated Go package name
+	ModelImport string            // Import path of the package of the model types
+	TypeImports map[string]string // Import path of types which are not in the model package (type name -> import path)
+	aliases     map[string]string // Package alias per import path
+}
+
+// NewGoClientProcessor - Factory method
+func NewGoClientProcessor(model *model.MetaModel, output string, pkg string, modelImport string) *GoClientProcessor {
+	return &GoClientProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Package:     pkg,
+		ModelImport: modelImport,
+		TypeImports: make(map[string]string),
+	}
+}
+
+// WithTypeImport sets the import path of type which is not declared in the model package (e.g. EntityResponse)
+func (p *GoClientProcessor) WithTypeImport(typeName string, importPath string) *GoClientProcessor {
+	p.TypeImports[typeName] = importPath
+	return p
+}
+
+// goServiceFile is the template data of a single service client file
+type goServiceFile struct {
+	Package string
+	Imports []string
+	Service *model.ServiceInfo
+}
+
+// Start the processor
+func (p *GoClientProcessor) Start() error {
+
+	p.aliases = p.buildAliases()
+
+	funcMap := template.FuncMap{
+		"goDocs":         goDocs,
+		"goDeprecated":   goDeprecated,
+		"goClientName":   goClientName,
+		"goMethodParams": p.goMethodParams,
+		"goMethodResult": p.goMethodResult,
+		"goMethodBody":   p.goMethodBody,
+		"join":           strings.Join,
+	}
+
+	// Generate the base client
+	if err := p.generate("client.go", goClientTemplate, funcMap, goServiceFile{Package: p.Package}); err != nil {
+		return err
+	}
+
+	// Generate all services
+	for _, service := range p.Model.ListServices() {
+		data := goServiceFile{
+			Package: p.Package,
+			Imports: p.listImports(service),
+			Service: service,
+		}
+		fileName := fmt.Sprintf("%s.go", toSnakeCase(goClientName(service)))
+		if err := p.generate(fileName, goServiceTemplate, funcMap, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute template, format the source and write the result to file
+func (p *GoClientProcessor) generate(fileName, source string, funcMap template.FuncMap, data any) error {
+	tmpl, err := template.New(fileName).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", fileName, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", fileName, err.Error())
+	}
+
+	content, err := format.Source(tpl.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting generated source [%s]: %s", fileName, err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, fileName), string(content))
+}
+
+// Build unique package alias for every import path
+func (p *GoClientProcessor) buildAliases() map[string]string {
+	paths := []string{p.ModelImport}
+	for _, importPath := range p.TypeImports {
+		paths = append(paths, importPath)
+	}
+	sort.Strings(paths[1:])
+
+	aliases := make(map[string]string)
+	used := make(map[string]bool)
+	for _, importPath := range paths {
+		if _, ok := aliases[importPath]; ok {
+			continue
+		}
+		alias := path.Base(importPath)
+		for i := 2; used[alias]; i++ {
+			alias = fmt.Sprintf("%s%d", path.Base(importPath), i)
+		}
+		used[alias] = true
+		aliases[importPath] = alias
+	}
+	return aliases
+}
+
+// List the import paths (with aliases) required by the service
+func (p *GoClientProcessor) listImports(service *model.ServiceInfo) []string {
+	std := make(map[string]bool)
+	required := make(map[string]bool)
+	for _, mi := range service.Methods {
+		if !mi.IsFileUpload {
+			std["context"] = true
+		}
+		if len(mi.PathParams) > 0 {
+			std["fmt"], std["net/url"], std["strings"] = true, true, true
+		}
+		if len(mi.QueryParams) > 0 {
+			std["net/url"] = true
+		}
+		if mi.FileParam != nil && !mi.IsFileUpload {
+			std["io"] = true
+		}
+		for _, param := range listMethodParams(*mi) {
+			if param.ParamType != "file" {
+				p.collectImports(model.NewTypeNode(param.Type), required)
+			}
+		}
+		if !mi.IsFileUpload {
+			p.collectImports(mi.ReturnType, required)
+		}
+	}
+
+	list := make([]string, 0)
+	for importPath := range std {
+		list = append(list, strconv.Quote(importPath))
+	}
+	sort.Strings(list)
+
+	// Separate standard library imports from the model imports
+	if len(required) > 0 {
+		list = append(list, "")
+	}
+	models := make([]string, 0)
+	for importPath := range required {
+		models = append(models, fmt.Sprintf("%s %q", p.aliases[importPath], importPath))
+	}
+	sort.Strings(models)
+	return append(list, models...)
+}
+
+// Collect the import paths of all the named types in the type node
+func (p *GoClientProcessor) collectImports(node *model.TypeNode, required map[string]bool) {
+	if node == nil {
+		return
+	}
+	if _, ok := goTypes[node.Name]; !ok {
+		required[p.typeImport(node.Name)] = true
+	}
+	for _, arg := range node.Args {
+		p.collectImports(arg, required)
+	}
+}
+
+// Get the import path of a named type
+func (p *GoClientProcessor) typeImport(name string) string {
+	if importPath, ok := p.TypeImports[name]; ok {
+		return importPath
+	}
+	return p.ModelImport
+}
+
+// Get the Go type of type node, named types are qualified by their package alias
+func (p *GoClientProcessor) goType(node *model.TypeNode) string {
+	if node == nil {
+		return "any"
+	}
+
+	result, ok := goTypes[node.Name]
+	if !ok {
+		result = fmt.Sprintf("%s.%s", p.aliases[p.typeImport(node.Name)], node.Name)
+	}
+	if len(node.Args) > 0 {
+		args := make([]string, 0)
+		for _, arg := range node.Args {
+			args = append(args, p.goType(arg))
+		}
+		result = fmt.Sprintf("%s[%s]", result, strings.Join(args, ", "))
+	}
+	if node.IsArray {
+		result = "[]" + result
+	}
+	return result
+}
+
+// Get the Go type of method parameter
+func (p *GoClientProcessor) goParamType(param *model.ParamInfo) string {
+	goType := p.goType(model.NewTypeNode(param.Type))
+	if param.IsArray {
+		goType = "[]" + goType
+	}
+	return goType
+}
+
+// Build method input parameters list, optional query parameters are pointers (or slices)
+func (p *GoClientProcessor) goMethodParams(mi *model.MethodInfo) string {
+	params := make([]string, 0)
+	if !mi.IsFileUpload {
+		params = append(params, "ctx context.Context")
+	}
+	for _, param := range listMethodParams(*mi) {
+		name := p.goName(param.Json)
+		switch param.ParamType {
+		case "file":
+			if !mi.IsFileUpload {
+				params = append(params, fmt.Sprintf("%s io.Reader", name))
+			}
+		case "query":
+			goType := p.goParamType(param)
+			if !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") {
+				goType = "*" + goType
+			}
+			params = append(params, fmt.Sprintf("%s %s", name, goType))
+		case "body":
+			if HasRequestBody(mi) {
+				params = append(params, fmt.Sprintf("%s %s", name, p.goParamType(param)))
+			}
+		default:
+			params = append(params, fmt.Sprintf("%s %s", name, p.goParamType(param)))
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// Get the method result types
+func (p *GoClientProcessor) goMethodResult(mi *model.MethodInfo) string {
+	if mi.IsFileUpload {
+		return "string"
+	}
+	if mi.ReturnType == nil {
+		return "error"
+	}
+	if mi.ReturnClass == "StreamContent" {
+		return "([]byte, error)"
+	}
+	return fmt.Sprintf("(*%s, error)", p.goType(mi.ReturnType))
+}
+
+// Build method content - invoke the base client
+func (p *GoClientProcessor) goMethodBody(si *model.ServiceInfo, mi *model.MethodInfo) string {
+	lines := []string{fmt.Sprintf("path := %q", si.MethodPath(mi))}
+	for _, param := range mi.PathParams {
+		lines = append(lines, fmt.Sprintf("path = strings.ReplaceAll(path, %q, url.PathEscape(fmt.Sprint(%s)))",
+			fmt.Sprintf("{%s}", param.Json), p.goName(param.Json)))
+	}
+
+	query := "nil"
+	if len(mi.QueryParams) > 0 {
+		query = "query"
+		lines = append(lines, "query := url.Values{}")
+		for _, param := range mi.QueryParams {
+			lines = append(lines, fmt.Sprintf("addQueryParam(query, %q, %s)", param.Json, p.goName(param.Json)))
+		}
+	}
+
+	// Upload handler returns the upload URL
+	if mi.IsFileUpload {
+		return strings.Join(append(lines, fmt.Sprintf("return c.URL(path, %s)", query)), "\n")
+	}
+
+	body := "nil"
+	if HasRequestBody(mi) {
+		body = p.goName(mi.BodyParam.Json)
+	}
+	file := "nil"
+	if mi.FileParam != nil {
+		file = p.goName(mi.FileParam.Json)
+	}
+	call := fmt.Sprintf("c.Do(ctx, %q, path, %s, %s, %s", strings.ToUpper(mi.Method), query, body, file)
+
+	switch {
+	case mi.ReturnType == nil:
+		lines = append(lines, fmt.Sprintf("return %s, nil)", call))
+	case mi.ReturnClass == "StreamContent":
+		lines = append(lines, "var out []byte", fmt.Sprintf("err := %s, &out)", call), "return out, err")
+	default:
+		lines = append(lines,
+			fmt.Sprintf("out := new(%s)", p.goType(mi.ReturnType)),
+			fmt.Sprintf("if err := %s, out); err != nil {", call),
+			"return nil, err",
+			"}",
+			"return out, nil")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// convert parameter name to Go identifier which does not collide with keywords, packages or local variables
+func (p *GoClientProcessor) goName(name string) string {
+	result := toCamelCase(name)
+	reserved := map[string]bool{
+		"c": true, "ctx": true, "path": true, "query": true, "out": true, "err": true,
+		"url": true, "fmt": true, "io": true, "strings": true, "context": true,
+	}
+	for _, alias := range p.aliases {
+		reserved[alias] = true
+	}
+	if token.IsKeyword(result) || reserved[result] {
+		result += "Param"
+	}
+	return result
+}
+
+// Get the client struct name of the service
+func goClientName(service *model.ServiceInfo) string {
+	name := service.TsName
+	if len(name) == 0 {
+		name = service.Name
+	}
+	return model.Title(name) + "Client"
+}
+
+// Build Go comment from documentation lines
+func goDocs(docs []string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
+	}
+	output := ""
+	for _, line := range strings.Split(text, "\n") {
+		output += fmt.Sprintf("// %s\n", line)
+	}
+	return output
+}
+
+// Build Go deprecation paragraph (follows the documentation comment)
+func goDeprecated(deprecated *model.DeprecationInfo) string {
+	if deprecated == nil {
+		return ""
+	}
+	return fmt.Sprintf("//\n// Deprecated: %s\n", deprecated)
+}
+
+// region Go client templates ------------------------------------------------------------------------------------------
+
+var goClientTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Client is the HTTP client shared by all the service clients
+type Client struct {
+	BaseURL    string       // Base URL of the API (e.g. https://api.example.com/v1)
+	Headers    http.Header  // Headers to send with every request (e.g. X-API-KEY)
+	HTTPClient *http.Client // Underlying HTTP client
+}
+
+// NewClient creates new client for the API base URL
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Headers:    make(http.Header),
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// WithHeader sets header to send with every request
+func (c *Client) WithHeader(key, value string) *Client {
+	c.Headers.Set(key, value)
+	return c
+}
+
+// Error is returned for non successful HTTP status codes
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("http error %d: %s", e.StatusCode, string(e.Body))
+}
+
+// Ptr returns pointer to value (used for optional query parameters)
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// URL builds the full URL of a resource path
+func (c *Client) URL(path string, query url.Values) string {
+	if len(query) == 0 {
+		return c.BaseURL + path
+	}
+	return c.BaseURL + path + "?" + query.Encode()
+}
+
+// Do sends the request and decodes the JSON response into out (raw content for *[]byte)
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, file io.Reader, out any) error {
+	var reader io.Reader
+	contentType := ""
+
+	if file != nil {
+		buffer := &bytes.Buffer{}
+		writer := multipart.NewWriter(buffer)
+		part, err := writer.CreateFormFile("fileKey", "file")
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(part, file); err != nil {
+			return err
+		}
+		if err = writer.Close(); err != nil {
+			return err
+		}
+		reader, contentType = buffer, writer.FormDataContentType()
+	} else if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader, contentType = bytes.NewReader(content), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL(path, query), reader)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Headers {
+		req.Header[key] = values
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &Error{StatusCode: resp.StatusCode, Body: content}
+	}
+
+	switch target := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*target = content
+		return nil
+	default:
+		if len(content) == 0 {
+			return nil
+		}
+		return json.Unmarshal(content, out)
+	}
+}
+
+// add optional query parameter, nil values are omitted and lists are sent as comma separated values
+func addQueryParam(query url.Values, key string, value any) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || ((v.Kind() == reflect.Pointer || v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()) {
+		return
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, fmt.Sprint(v.Index(i).Interface()))
+		}
+		query.Set(key, strings.Join(items, ","))
+		return
+	}
+	query.Set(key, fmt.Sprint(v.Interface()))
+}
+`
+
+var goServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .Imports}}
+	{{.}}{{end}}
+)
+
+{{with .Service}}{{$service := .}}{{$name := goClientName .}}
+{{goDocs .Docs}}{{with .Headers}}// Expected HTTP headers (set them in Client.Headers): {{join . ", "}}
+{{end}}{{goDeprecated .Deprecated}}type {{$name}} struct {
+	*Client
+}
+
+// New{{$name}} creates new {{.TsName}} client
+func New{{$name}}(client *Client) *{{$name}} {
+	return &{{$name}}{Client: client}
+}
+{{range .Methods}}
+{{goDocs .Docs}}{{goDeprecated .Deprecated}}func (c *{{$name}}) {{.Name}}({{goMethodParams .}}) {{goMethodResult .}} {
+	{{goMethodBody $service .}}
+}
+{{end}}{{end}}`
+
+// endregion
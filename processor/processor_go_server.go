@@ -0,0 +1,412 @@
+package processor
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// GoServerProcessor - Go server processor converts the services to Go route registration code: handler interface per
+// service (implemented with the original Go types) and function registering the annotated methods on net/http ServeMux
+// (Go 1.22 method and pattern routes) or any router adapter, including path / query parameters parsing and JSON decoding
+// of the body, so the @Http annotations are the single source of truth of the routes
+type GoServerProcessor struct {
+	GoClientProcessor
+}
+
+// NewGoServerProcessor - Factory method
+func NewGoServerProcessor(model *model.MetaModel, output string, pkg string, modelImport string) *GoServerProcessor {
+	return &GoServerProcessor{GoClientProcessor: *NewGoClientProcessor(model, output, pkg, modelImport)}
+}
+
+// WithTypeImport sets the import path of type which is not declared in the model package (e.g. EntityResponse)
+func (p *GoServerProcessor) WithTypeImport(typeName string, importPath string) *GoServerProcessor {
+	p.TypeImports[typeName] = importPath
+	return p
+}
+
+// Start the processor
+func (p *GoServerProcessor) Start() error {
+
+	p.aliases = p.buildAliases()
+
+	funcMap := template.FuncMap{
+		"goDocs":          goDocs,
+		"goDeprecated":    goDeprecated,
+		"goServiceName":   goServiceName,
+		"goHandlerName":   goHandlerName,
+		"goRoutePattern":  goRoutePattern,
+		"goHandlerParams": p.goHandlerParams,
+		"goHandlerResult": p.goHandlerResult,
+		"goHandlerBody":   p.goHandlerBody,
+	}
+
+	// Generate the router helpers
+	if err := p.generate("server.go", goServerTemplate, funcMap, goServiceFile{Package: p.Package}); err != nil {
+		return err
+	}
+
+	// Generate the routes of all services
+	for _, service := range p.Model.ListServices() {
+		data := goServiceFile{
+			Package: p.Package,
+			Imports: p.listServerImports(service),
+			Service: service,
+		}
+		fileName := fmt.Sprintf("%s_routes.go", toSnakeCase(goServiceName(service)))
+		if err := p.generate(fileName, goRoutesTemplate, funcMap, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List the import paths (with aliases) required by the service routes
+func (p *GoServerProcessor) listServerImports(service *model.ServiceInfo) []string {
+	std := map[string]bool{"context": true, "net/http": true}
+	required := make(map[string]bool)
+	for _, mi := range goRouteMethods(service) {
+		if HasRequestBody(mi) {
+			std["encoding/json"] = true
+		}
+		if mi.FileParam != nil {
+			std["io"] = true
+		}
+		for _, param := range listMethodParams(*mi) {
+			if param.ParamType != "file" {
+				p.collectImports(model.NewTypeNode(param.Type), required)
+			}
+		}
+		p.collectImports(mi.ReturnType, required)
+	}
+
+	list := make([]string, 0)
+	for importPath := range std {
+		list = append(list, strconv.Quote(importPath))
+	}
+	sort.Strings(list)
+
+	// Separate standard library imports from the model imports
+	if len(required) > 0 {
+		list = append(list, "")
+	}
+	models := make([]string, 0)
+	for importPath := range required {
+		models = append(models, fmt.Sprintf("%s %q", p.aliases[importPath], importPath))
+	}
+	sort.Strings(models)
+	return append(list, models...)
+}
+
+// Build the handler method input parameters list, optional query parameters are pointers (or slices)
+func (p *GoServerProcessor) goHandlerParams(mi *model.MethodInfo) string {
+	params := []string{"ctx context.Context"}
+	for _, param := range listMethodParams(*mi) {
+		name := p.goLocalName(param.Json)
+		switch param.ParamType {
+		case "file":
+			params = append(params, fmt.Sprintf("%s io.Reader", name))
+		case "query":
+			params = append(params, fmt.Sprintf("%s %s", name, p.goQueryType(param)))
+		case "body":
+			if HasRequestBody(mi) {
+				params = append(params, fmt.Sprintf("%s %s", name, p.goParamType(param)))
+			}
+		default:
+			params = append(params, fmt.Sprintf("%s %s", name, p.goParamType(param)))
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// Get the handler method result types
+func (p *GoServerProcessor) goHandlerResult(mi *model.MethodInfo) string {
+	if mi.ReturnType == nil {
+		return "error"
+	}
+	if mi.ReturnClass == "StreamContent" {
+		return "([]byte, error)"
+	}
+	return fmt.Sprintf("(*%s, error)", p.goType(mi.ReturnType))
+}
+
+// Get the Go type of query parameter (pointer, or slice of list parameter)
+func (p *GoServerProcessor) goQueryType(param *model.ParamInfo) string {
+	goType := p.goParamType(param)
+	if !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") {
+		goType = "*" + goType
+	}
+	return goType
+}
+
+// Build the route handler content: extract the parameters, invoke the service and write the response
+func (p *GoServerProcessor) goHandlerBody(mi *model.MethodInfo) string {
+	lines := make([]string, 0)
+	args := []string{"r.Context()"}
+	for _, param := range listMethodParams(*mi) {
+		name := p.goLocalName(param.Json)
+		switch param.ParamType {
+		case "path":
+			lines = append(lines,
+				fmt.Sprintf("var %s %s", name, p.goParamType(param)),
+				fmt.Sprintf("if err := parseParam(r.PathValue(%q), &%s); err != nil {", param.Json, name),
+				fmt.Sprintf("writeError(w, paramError(%q, err))", param.Json),
+				"return",
+				"}")
+		case "query":
+			goType := p.goQueryType(param)
+			lines = append(lines,
+				fmt.Sprintf("var %s %s", name, goType),
+				fmt.Sprintf("if text := r.URL.Query().Get(%q); len(text) > 0 {", param.Json))
+			target := "&" + name
+			if strings.HasPrefix(goType, "*") {
+				lines = append(lines, fmt.Sprintf("%s = new(%s)", name, goType[1:]))
+				target = name
+			}
+			lines = append(lines,
+				fmt.Sprintf("if err := parseParam(text, %s); err != nil {", target),
+				fmt.Sprintf("writeError(w, paramError(%q, err))", param.Json),
+				"return",
+				"}",
+				"}")
+		case "body":
+			if !HasRequestBody(mi) {
+				continue
+			}
+			lines = append(lines,
+				fmt.Sprintf("var %s %s", name, p.goParamType(param)),
+				fmt.Sprintf("if err := json.NewDecoder(r.Body).Decode(&%s); err != nil {", name),
+				fmt.Sprintf("writeError(w, paramError(%q, err))", param.Json),
+				"return",
+				"}")
+		case "file":
+			lines = append(lines,
+				fmt.Sprintf("%s, _, err := r.FormFile(\"fileKey\")", name),
+				"if err != nil {",
+				fmt.Sprintf("writeError(w, paramError(%q, err))", param.Json),
+				"return",
+				"}",
+				fmt.Sprintf("defer func() { _ = %s.Close() }()", name))
+		}
+		if param.ParamType != "body" || HasRequestBody(mi) {
+			args = append(args, name)
+		}
+	}
+
+	call := fmt.Sprintf("service.%s(%s)", mi.Name, strings.Join(args, ", "))
+	switch {
+	case mi.ReturnType == nil:
+		lines = append(lines,
+			fmt.Sprintf("if err := %s; err != nil {", call),
+			"writeError(w, err)",
+			"return",
+			"}",
+			"w.WriteHeader(http.StatusNoContent)")
+	case mi.ReturnClass == "StreamContent":
+		lines = append(lines,
+			fmt.Sprintf("content, err := %s", call),
+			"if err != nil {",
+			"writeError(w, err)",
+			"return",
+			"}",
+			"w.Header().Set(\"Content-Type\", \"application/octet-stream\")",
+			"_, _ = w.Write(content)")
+	default:
+		lines = append(lines,
+			fmt.Sprintf("result, err := %s", call),
+			"if err != nil {",
+			"writeError(w, err)",
+			"return",
+			"}",
+			"writeJSON(w, result)")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// convert parameter name to Go identifier which does not collide with keywords, packages or the handler variables
+func (p *GoServerProcessor) goLocalName(name string) string {
+	result := toCamelCase(name)
+	reserved := map[string]bool{
+		"w": true, "r": true, "ctx": true, "err": true, "text": true, "result": true, "content": true,
+		"service": true, "router": true, "json": true, "http": true, "io": true, "context": true,
+	}
+	for _, alias := range p.aliases {
+		reserved[alias] = true
+	}
+	if token.IsKeyword(result) || reserved[result] {
+		result += "Param"
+	}
+	return result
+}
+
+// List the service methods which are routed (socket messages are not HTTP routes)
+func goRouteMethods(service *model.ServiceInfo) []*model.MethodInfo {
+	list := make([]*model.MethodInfo, 0)
+	for _, mi := range service.Methods {
+		if !mi.IsSocketMessage && len(mi.Method) > 0 {
+			list = append(list, mi)
+		}
+	}
+	return list
+}
+
+// Get the route pattern of method (e.g. GET /users/{id})
+func goRoutePattern(service *model.ServiceInfo, mi *model.MethodInfo) string {
+	route := service.MethodPath(mi)
+	if len(route) == 0 {
+		route = "/"
+	}
+	return fmt.Sprintf("%s %s", strings.ToUpper(mi.Method), route)
+}
+
+// Get the service name (TypeScript name if exists)
+func goServiceName(service *model.ServiceInfo) string {
+	name := service.TsName
+	if len(name) == 0 {
+		name = service.Name
+	}
+	return model.Title(name)
+}
+
+// Get the handler interface name of the service
+func goHandlerName(service *model.ServiceInfo) string {
+	return goServiceName(service) + "Handler"
+}
+
+// region Go server templates ------------------------------------------------------------------------------------------
+
+var goServerTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Router registers the route handlers, the pattern is "METHOD /path/{param}" (Go 1.22 http.ServeMux pattern).
+// *http.ServeMux implements it, adapters of other routers must set the path parameters with r.SetPathValue
+type Router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Error is returned by the handlers to set the HTTP status code of the error response (other errors are 500)
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError creates error with HTTP status code
+func NewError(statusCode int, message string) *Error {
+	return &Error{StatusCode: statusCode, Message: message}
+}
+
+// build bad request error of invalid parameter
+func paramError(name string, err error) error {
+	return NewError(http.StatusBadRequest, fmt.Sprintf("invalid parameter %s: %s", name, err))
+}
+
+// write the value as JSON response
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// write JSON error response, the status code is taken from *Error (500 for other errors)
+func writeError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	var httpError *Error
+	if errors.As(err, &httpError) {
+		statusCode = httpError.StatusCode
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": statusCode, "error": err.Error()})
+}
+
+// parse path or query parameter to the target (strings, numbers, booleans and their named types such as enums), lists
+// are comma separated values
+func parseParam(text string, target any) error {
+	v := reflect.ValueOf(target).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		items := strings.Split(text, ",")
+		list := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := parseParam(item, list.Index(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		v.Set(list)
+	default:
+		return fmt.Errorf("unsupported parameter type %s", v.Type())
+	}
+	return nil
+}
+`
+
+var goRoutesTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .Imports}}
+	{{.}}{{end}}
+)
+
+{{with .Service}}{{$service := .}}{{$name := goHandlerName .}}
+{{goDocs .Docs}}{{goDeprecated .Deprecated}}type {{$name}} interface {
+{{range .Methods}}{{if and (not .IsSocketMessage) .Method}}
+	{{goDocs .Docs}}{{goDeprecated .Deprecated}}{{.Name}}({{goHandlerParams .}}) {{goHandlerResult .}}
+{{end}}{{end}}}
+
+// Register{{goServiceName .}}Routes registers the {{.TsName}} methods routes on the router (e.g. http.ServeMux)
+func Register{{goServiceName .}}Routes(router Router, service {{$name}}) {
+{{range .Methods}}{{if and (not .IsSocketMessage) .Method}}
+	router.HandleFunc({{printf "%q" (goRoutePattern $service .)}}, func(w http.ResponseWriter, r *http.Request) {
+		{{goHandlerBody .}}
+	})
+{{end}}{{end}}}
+{{end}}`
+
+// endregion
@@ -0,0 +1,413 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var graphqlTypes = map[string]string{
+	"double":        "Float",
+	"float":         "Float",
+	"float32":       "Float",
+	"float64":       "Float",
+	"int":           "Long",
+	"int32":         "Int",
+	"int64":         "Long",
+	"uint":          "Long",
+	"uint32":        "Long",
+	"uint64":        "Long",
+	"sint":          "Long",
+	"sint32":        "Int",
+	"sint64":        "Long",
+	"fixed32":       "Long",
+	"fixed64":       "Long",
+	"sfixed32":      "Int",
+	"sfixed64":      "Long",
+	"bool":          "Boolean",
+	"string":        "String",
+	"bytes":         "String",
+	"any":           "JSON",
+	"Timestamp":     "Long",
+	"Json":          "JSON",
+	"StreamContent": "String",
+	"number":        "Float",
+	"boolean":       "Boolean",
+}
+
+// Custom scalars and their descriptions
+var graphqlScalars = map[string]string{
+	"Long":   "64 bit integer",
+	"JSON":   "Arbitrary JSON value",
+	"Upload": "File upload (multipart request)",
+}
+
+// GraphQLProcessor - GraphQL processor converts the meta model to GraphQL schema (SDL)
+type GraphQLProcessor struct {
+	BaseProcessor
+}
+
+// NewGraphQLProcessor - Factory method
+func NewGraphQLProcessor(model *model.MetaModel, output string) *GraphQLProcessor {
+	return &GraphQLProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+type graphqlSchema struct {
+	Scalars   []string
+	Enums     []*graphqlEnum
+	Types     []*graphqlType
+	Inputs    []*graphqlType
+	Queries   []*graphqlField
+	Mutations []*graphqlField
+}
+
+type graphqlEnum struct {
+	Name   string
+	Docs   []string
+	Values []*graphqlEnumValue
+}
+
+type graphqlEnumValue struct {
+	Name       string
+	Docs       []string
+	Deprecated string // Deprecation reason (empty if not deprecated)
+}
+
+type graphqlType struct {
+	Name   string
+	Docs   []string
+	Fields []*graphqlField
+}
+
+type graphqlField struct {
+	Name       string
+	Type       string
+	Docs       []string
+	Args       []*graphqlField
+	Service    string // Service of the operation field
+	Deprecated string // Deprecation reason (empty if not deprecated)
+}
+
+// graphqlBuilder collects the schema types, generic classes are generated per type arguments
+type graphqlBuilder struct {
+	mm      *model.MetaModel
+	scalars map[string]bool
+	added   map[string]bool
+	schema  *graphqlSchema
+}
+
+// Start the processor
+func (p *GraphQLProcessor) Start() error {
+
+	b := &graphqlBuilder{
+		mm:      p.Model,
+		scalars: make(map[string]bool),
+		added:   make(map[string]bool),
+		schema:  &graphqlSchema{},
+	}
+
+	for _, enum := range p.Model.ListEnums() {
+		b.schema.Enums = append(b.schema.Enums, b.buildEnum(enum))
+	}
+
+	// Generic classes are generated only for their actual type arguments
+	for _, class := range p.Model.ListClasses() {
+		if !class.IsParam && !class.IsGeneric {
+			b.addClass(class, nil, false)
+		}
+	}
+
+	// GET methods are queries, all other methods are mutations
+	for _, service := range p.Model.ListServices() {
+		for _, mi := range service.Methods {
+			field := b.buildOperation(service, mi)
+			if strings.ToUpper(mi.Method) == "GET" {
+				b.schema.Queries = append(b.schema.Queries, field)
+			} else {
+				b.schema.Mutations = append(b.schema.Mutations, field)
+			}
+		}
+	}
+	graphqlUniqueNames(b.schema.Queries)
+	graphqlUniqueNames(b.schema.Mutations)
+
+	for scalar := range b.scalars {
+		b.schema.Scalars = append(b.schema.Scalars, scalar)
+	}
+	sort.Strings(b.schema.Scalars)
+
+	funcMap := template.FuncMap{
+		"gqlDocs":       gqlDocs,
+		"gqlArgs":       gqlArgs,
+		"gqlDeprecated": gqlDeprecated,
+		"scalarDocs":    func(name string) []string { return []string{graphqlScalars[name]} },
+		"hasOperations": func(list []*graphqlField) bool { return len(list) > 0 },
+	}
+
+	tmpl, err := template.New("schema.graphql").Funcs(funcMap).Parse(graphqlTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template [schema.graphql]: %s", err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, b.schema); err != nil {
+		return fmt.Errorf("error executing template [schema.graphql]: %s", err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, "schema.graphql"), p.trimNewLines(tpl.String()))
+}
+
+// Build GraphQL enum, values are the enum value names (the numeric values are not part of the schema)
+func (b *graphqlBuilder) buildEnum(enum *model.EnumInfo) *graphqlEnum {
+	result := &graphqlEnum{Name: enum.Name, Docs: deprecatedDocs(enum.Docs, enum.Deprecated)}
+	for _, ev := range enum.Values {
+		name := gqlName(strings.ToUpper(toSnakeCase(ev.Name)))
+		if name == "TRUE" || name == "FALSE" || name == "NULL" {
+			name += "_VALUE"
+		}
+		value := &graphqlEnumValue{Name: name, Docs: ev.Docs}
+		if ev.Deprecated != nil {
+			value.Deprecated = ev.Deprecated.String()
+		}
+		result.Values = append(result.Values, value)
+	}
+	return result
+}
+
+// Add object type (or input type) of class and return the type name
+func (b *graphqlBuilder) addClass(class *model.ClassInfo, args []*model.TypeNode, input bool) string {
+	name, bindings := bindGenericTypes(class, args)
+	if input {
+		name += "Input"
+	}
+	if b.added[name] {
+		return name
+	}
+	b.added[name] = true
+
+	gt := &graphqlType{Name: name, Docs: deprecatedDocs(class.Docs, class.Deprecated)}
+	if input {
+		b.schema.Inputs = append(b.schema.Inputs, gt)
+	} else {
+		b.schema.Types = append(b.schema.Types, gt)
+	}
+
+	for _, field := range b.mm.ListClassFields(class) {
+		node := substituteTypeNode(model.NewTypeNodeFromGoType(field.Type), bindings)
+		gqlType := b.resolveType(node, input)
+		if field.IsArray {
+			gqlType = fmt.Sprintf("[%s]", gqlType)
+		}
+		gf := &graphqlField{Name: gqlName(field.Json), Type: gqlType, Docs: field.Docs}
+
+		// Input fields deprecation is not supported by all the tools, it is documented only
+		if field.Deprecated != nil && input {
+			gf.Docs = deprecatedDocs(gf.Docs, field.Deprecated)
+		} else if field.Deprecated != nil {
+			gf.Deprecated = field.Deprecated.String()
+		}
+		gt.Fields = append(gt.Fields, gf)
+	}
+
+	// Type without fields is not valid in GraphQL
+	if len(gt.Fields) == 0 {
+		gt.Fields = append(gt.Fields, &graphqlField{Name: "_empty", Type: b.useScalar("Boolean"), Docs: []string{"Placeholder of class without fields"}})
+	}
+	return name
+}
+
+// Resolve the GraphQL type of type node (after generic type substitution)
+func (b *graphqlBuilder) resolveType(node *model.TypeNode, input bool) string {
+	result := b.useScalar("JSON")
+	if node == nil {
+		return result
+	}
+	if gqlType, ok := graphqlTypes[node.Name]; ok {
+		result = b.useScalar(gqlType)
+	} else if b.mm.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := b.mm.GetClass(node.Name); class != nil {
+		result = b.addClass(class, node.Args, input)
+	}
+	if node.IsArray {
+		result = fmt.Sprintf("[%s]", result)
+	}
+	return result
+}
+
+// Register custom scalar
+func (b *graphqlBuilder) useScalar(name string) string {
+	if _, ok := graphqlScalars[name]; ok {
+		b.scalars[name] = true
+	}
+	return name
+}
+
+// Build Query or Mutation field of service method, arguments are the method parameters
+func (b *graphqlBuilder) buildOperation(service *model.ServiceInfo, mi *model.MethodInfo) *graphqlField {
+	field := &graphqlField{
+		Name:    gqlName(toCamelCase(mi.Name)),
+		Type:    b.useScalar("Boolean"),
+		Docs:    append(append(make([]string, 0), mi.Docs...), "", "HTTP: "+service.MethodRoute(mi)),
+		Service: service.TsName,
+	}
+	if mi.ReturnType != nil {
+		field.Type = b.resolveType(mi.ReturnType, false)
+	}
+	if deprecated := methodDeprecation(service, mi); deprecated != nil {
+		field.Deprecated = deprecated.String()
+	}
+
+	for _, param := range listMethodParams(*mi) {
+		if param.ParamType == "body" && !HasRequestBody(mi) {
+			continue
+		}
+		var gqlType string
+		switch param.ParamType {
+		case "file":
+			gqlType = b.useScalar("Upload") + "!"
+		case "query":
+			gqlType = b.resolveType(model.NewTypeNode(param.Type), true)
+		default:
+			gqlType = b.resolveType(model.NewTypeNode(param.Type), true) + "!"
+		}
+		if param.IsArray {
+			gqlType = fmt.Sprintf("[%s]", strings.TrimSuffix(gqlType, "!"))
+		}
+		field.Args = append(field.Args, &graphqlField{Name: gqlName(param.Json), Type: gqlType, Docs: param.Docs})
+	}
+	return field
+}
+
+// Operations with the same name (in different services) are prefixed with the service name
+func graphqlUniqueNames(fields []*graphqlField) {
+	count := make(map[string]int)
+	for _, field := range fields {
+		count[field.Name]++
+	}
+	for _, field := range fields {
+		if count[field.Name] < 2 {
+			continue
+		}
+		field.Name = gqlName(toCamelCase(strings.TrimSuffix(field.Service, "Service"))) + model.Title(field.Name)
+	}
+}
+
+// convert name to valid GraphQL name
+func gqlName(name string) string {
+	result := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			return r
+		}
+		return '_'
+	}, name)
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
+
+// Build GraphQL description from documentation lines
+func gqlDocs(docs []string, indent string) string {
+	text := strings.ReplaceAll(joinDocs(docs), `"""`, `\"""`)
+	if len(text) == 0 {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s\"\"\"%s\"\"\"\n", indent, text)
+	}
+	output := indent + "\"\"\"\n"
+	for _, line := range lines {
+		if len(line) == 0 {
+			output += "\n"
+		} else {
+			output += indent + line + "\n"
+		}
+	}
+	return output + indent + "\"\"\"\n"
+}
+
+// Build the deprecated directive
+func gqlDeprecated(reason string) string {
+	if len(reason) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" @deprecated(reason: %s)", gqlString(reason))
+}
+
+// Build GraphQL string literal (quote, backslash and control characters are escaped)
+func gqlString(value string) string {
+	var builder strings.Builder
+	builder.WriteRune('"')
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			builder.WriteString(fmt.Sprintf("\\u%04X", r))
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteRune('"')
+	return builder.String()
+}
+
+// Build field arguments list
+func gqlArgs(args []*graphqlField) string {
+	if len(args) == 0 {
+		return ""
+	}
+	output := "(\n"
+	for _, arg := range args {
+		output += gqlDocs(arg.Docs, "    ")
+		output += fmt.Sprintf("    %s: %s\n", arg.Name, arg.Type)
+	}
+	return output + "  )"
+}
+
+// region GraphQL template ---------------------------------------------------------------------------------------------
+
+var graphqlTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+{{range .Scalars}}
+{{gqlDocs (scalarDocs .) ""}}scalar {{.}}
+{{end}}
+{{range .Enums}}
+{{gqlDocs .Docs ""}}enum {{.Name}} {
+{{range .Values}}{{gqlDocs .Docs "  "}}  {{.Name}}{{gqlDeprecated .Deprecated}}
+{{end}}}
+{{end}}
+{{range .Types}}
+{{gqlDocs .Docs ""}}type {{.Name}} {
+{{range .Fields}}{{gqlDocs .Docs "  "}}  {{.Name}}: {{.Type}}{{gqlDeprecated .Deprecated}}
+{{end}}}
+{{end}}
+{{range .Inputs}}
+{{gqlDocs .Docs ""}}input {{.Name}} {
+{{range .Fields}}{{gqlDocs .Docs "  "}}  {{.Name}}: {{.Type}}
+{{end}}}
+{{end}}
+type Query {
+{{range .Queries}}{{gqlDocs .Docs "  "}}  {{.Name}}{{gqlArgs .Args}}: {{.Type}}{{gqlDeprecated .Deprecated}}
+{{else}}  """Placeholder, the API has no queries"""
+  _empty: Boolean
+{{end}}}
+{{if hasOperations .Mutations}}
+type Mutation {
+{{range .Mutations}}{{gqlDocs .Docs "  "}}  {{.Name}}{{gqlArgs .Args}}: {{.Type}}{{gqlDeprecated .Deprecated}}
+{{end}}}
+{{end}}`
+
+// endregion
@@ -1,395 +1,314 @@
 package processor
 
 import (
+	"bytes"
+	"embed"
 	"fmt"
-	"log"
-	"os"
-	"sort"
+	"html"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/go-yaaf/yaaf-code-gen/model"
 )
 
-var classes []model.ClassInfo
-var enums []model.EnumInfo
-var services []model.ServiceInfo
-var sockets []model.WebSocketInfo
+// htmlTemplates are the HTML documentation templates, style sheet and images shipped with the module
+//
+//go:embed templates/html
+var htmlTemplates embed.FS
 
-// HtmlProcessor - Html processor converts proto files to documentation files
+// htmlTemplatesRoot is the root folder of the HTML templates in the embedded file system
+const htmlTemplatesRoot = "templates/html"
+
+// htmlIdentifier matches type names in type expression (e.g. EntityResponse<User>)
+var htmlIdentifier = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_.]*`)
+
+// HtmlProcessor - Html processor converts the meta model to static HTML documentation site
 type HtmlProcessor struct {
 	BaseProcessor
+	BaseURL  string // Default base URL of the API explorer requests (e.g. https://api.example.com)
+	classes  []*model.ClassInfo
+	enums    []*model.EnumInfo
+	services []*model.ServiceInfo
+	sockets  []*model.WebSocketInfo
 }
 
 // NewHtmlProcessor - Factory method
-func NewHtmlProcessor(model *model.MetaModel, output string) Processor {
-	return &HtmlProcessor{BaseProcessor{
-		Output: output,
-		Model:  model,
-	}}
+func NewHtmlProcessor(model *model.MetaModel, output string) *HtmlProcessor {
+	return &HtmlProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+// WithBaseURL sets the default base URL of the API explorer requests (the user can change it in the browser)
+func (p *HtmlProcessor) WithBaseURL(url string) *HtmlProcessor {
+	p.BaseURL = url
+	return p
+}
+
+// htmlPage is the template data of a single documentation page
+type htmlPage struct {
+	Title      string // Page title
+	HasSockets bool   // Add link to the web sockets page in the navigation bar
+	Item       any    // The documented model element (or list of elements)
+}
+
+// htmlServicePage is the template data of service page
+type htmlServicePage struct {
+	Service *model.ServiceInfo
+	BaseURL string
+}
+
+// htmlGroup is a group of services (by the service @ResourceGroup)
+type htmlGroup struct {
+	Name     string
+	Services []*model.ServiceInfo
 }
 
 // Start the processor
 func (p *HtmlProcessor) Start() error {
 
-	// First, ensure output directory
-	if err := os.MkdirAll("./output/html/img", os.ModePerm); err != nil {
-		log.Fatal("Error creating folder: ./output/html", err)
+	p.classes = make([]*model.ClassInfo, 0)
+	for _, class := range p.Model.ListClasses() {
+		if class.IsVisible {
+			p.classes = append(p.classes, class)
+		}
 	}
+	p.enums = p.Model.ListEnums()
+	p.services = p.Model.ListServices()
+	p.sockets = p.Model.ListWebSockets()
 
-	if err := p.dirCopy("./templates/html/img", "./output/html/img"); err != nil {
-		log.Fatal("Error copy folder: ./templates/html/img: ", err)
+	// Copy the style sheet and images
+	if err := p.copyAssets(); err != nil {
+		return err
 	}
 
-	// Iterate over the packages and merge all classes
-	for _, v := range p.Model.Packages {
-
-		// Generate class pages and append to all classes
-		for _, class := range v.Classes {
-			if class.IsVisible {
-				classes = append(classes, *class)
-				p.generateClassPage(*class)
-			}
+	// Generate class pages
+	for _, class := range p.classes {
+		if err := p.generate(fmt.Sprintf("json_%s.html", class.Name), "json_data_class.html", class.Name, class); err != nil {
+			return err
 		}
+	}
 
-		// Generate enum pages and append to all enums
-		for _, enum := range v.Enums {
-			enums = append(enums, *enum)
-			p.generateEnumPage(*enum)
+	// Generate enum pages
+	for _, enum := range p.enums {
+		if err := p.generate(fmt.Sprintf("json_%s.html", enum.Name), "json_data_enum.html", enum.Name, enum); err != nil {
+			return err
 		}
+	}
 
-		// Generate service pages and append to all services
-		for _, service := range v.Services {
-			services = append(services, *service)
-			p.generateServicePage(*service)
+	// Generate service pages
+	for _, service := range p.services {
+		if err := p.generate(fmt.Sprintf("resource_%s.html", service.Name), "json_data_service.html", service.Name, htmlServicePage{Service: service, BaseURL: p.BaseURL}); err != nil {
+			return err
 		}
+	}
 
-		// Generate Web Socket service pages and append to all socket services
-		for _, socket := range v.Sockets {
-			sockets = append(sockets, *socket)
-			p.generateWebSocketPage(*socket)
+	// Generate Web Socket pages
+	for _, socket := range p.sockets {
+		if err := p.generate(fmt.Sprintf("web_socket_%s.html", socket.Name), "json_web_socket.html", socket.Name, socket); err != nil {
+			return err
 		}
 	}
 
-	p.generateClassesTable(classes)
-	p.generateEnumsTable(enums)
-	p.generateResources(services)
-	p.generateWebSocketsPage(sockets)
-	p.generateCSS()
-
-	return nil
-}
-
-// Generate CSS files
-func (p *HtmlProcessor) generateCSS() {
-	tmpl, _ := template.New("style.css").ParseFiles("templates/html/style.css")
-	f, err := os.Create("./output/html/style.css")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
+	// Generate the index pages
+	if err := p.generate("index.html", "index.html", "Resources", listServicesGroups(p.services)); err != nil {
+		return err
 	}
-	if er := tmpl.Execute(f, ""); er != nil {
-		log.Fatal("Error executing template", tmpl.Name(), er)
+	if err := p.generate("dataTypes.html", "data_types.html", "Data Types", p.classes); err != nil {
+		return err
 	}
-}
-
-// Generate class page
-func (p *HtmlProcessor) generateClassPage(class model.ClassInfo) {
-	funcMap := template.FuncMap{
-		"getType": getType,
+	if err := p.generate("enums.html", "enums.html", "Enums", p.enums); err != nil {
+		return err
 	}
-	tmpl, _ := template.New("base.html").Funcs(funcMap).ParseFiles("templates/html/footer.html", "templates/html/json_data_class.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/json_" + class.Name + ".html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if er := tmpl.Execute(f, class); er != nil {
-		log.Fatal("Error executing template", tmpl.Name(), er)
-	}
-}
-
-// Generate enum page
-func (p *HtmlProcessor) generateEnumPage(enum model.EnumInfo) {
-	tmpl, _ := template.New("base.html").ParseFiles("templates/html/footer.html", "templates/html/json_data_enum.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/json_" + enum.Name + ".html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if er := tmpl.Execute(f, enum); er != nil {
-		log.Fatal("Error executing template", tmpl.Name(), er)
+	if len(p.sockets) > 0 {
+		return p.generate("webSockets.html", "web_sockets.html", "Web Sockets", p.sockets)
 	}
+	return nil
 }
 
-// Generate service page
-func (p *HtmlProcessor) generateServicePage(service model.ServiceInfo) {
+// Execute page template (wrapped by the base layout) and write the output file
+func (p *HtmlProcessor) generate(fileName string, page string, title string, item any) error {
 	funcMap := template.FuncMap{
-		"getType":      getType,
-		"addBodyParam": addBodyParam,
-		"addParams":    addParams,
-	}
-	tmpl, _ := template.New("base.html").Funcs(funcMap).ParseFiles("templates/html/footer.html", "templates/html/json_data_service.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/resource_" + service.Name + ".html")
+		"docs":              htmlDocs,
+		"docsLine":          docsLine,
+		"typeLink":          p.typeLink,
+		"fieldType":         p.fieldType,
+		"paramType":         p.paramType,
+		"returnType":        p.returnType,
+		"listClassFields":   p.Model.ListClassFields,
+		"listMethodParams":  func(mi *model.MethodInfo) []*model.ParamInfo { return listMethodParams(*mi) },
+		"methodDeprecation": methodDeprecation,
+		"methodRoute":       (*model.ServiceInfo).MethodPath,
+		"methodHeaders":     htmlMethodHeaders,
+		"hasRequestBody":    HasRequestBody,
+		"bodySample":        p.bodySample,
+		"responseSample":    func(mi *model.MethodInfo) string { return sampleResponse(p.Model, mi) },
+		"paramExample":      p.paramExample,
+		"deprecatedBadge":   deprecatedBadge,
+		"anchor":            removeSpaces,
+	}
+
+	files := []string{
+		path.Join(htmlTemplatesRoot, "base.html"),
+		path.Join(htmlTemplatesRoot, "footer.html"),
+		path.Join(htmlTemplatesRoot, page),
+	}
+	tmpl, err := template.New("base.html").Funcs(funcMap).ParseFS(htmlTemplates, files...)
 	if err != nil {
-		fmt.Println("create file: ", err)
-		return
+		return fmt.Errorf("error parsing template [%s]: %s", page, err.Error())
 	}
-	if er := tmpl.Execute(f, service); er != nil {
-		log.Fatal("Error executing template", tmpl.Name(), er)
+
+	var tpl bytes.Buffer
+	data := htmlPage{Title: title, HasSockets: len(p.sockets) > 0, Item: item}
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", page, err.Error())
 	}
+	return p.writeFile(path.Join(p.Output, fileName), p.trimNewLines(tpl.String()))
 }
 
-// Generate web-socket page
-func (p *HtmlProcessor) generateWebSocketPage(socket model.WebSocketInfo) {
-	funcMap := template.FuncMap{
-		"getType":      getType,
-		"addBodyParam": addBodyParam,
-		"addParams":    addParams,
-	}
-	tmpl, _ := template.New("base.html").Funcs(funcMap).ParseFiles("templates/html/footer.html", "templates/html/json_web_socket.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/web_socket_" + socket.Name + ".html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if er := tmpl.Execute(f, socket); er != nil {
-		log.Fatal("Error executing template", tmpl.Name(), er)
-	}
+// Copy the style sheet and images from the embedded templates to the output folder
+func (p *HtmlProcessor) copyAssets() error {
+	return fs.WalkDir(htmlTemplates, htmlTemplatesRoot, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(filePath) == ".html" {
+			return err
+		}
+		content, err := htmlTemplates.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		return p.writeFile(path.Join(p.Output, strings.TrimPrefix(filePath, htmlTemplatesRoot+"/")), string(content))
+	})
 }
 
-func listServicesGroups(services []model.ServiceInfo) map[string][]model.ServiceInfo {
-	groups := make(map[string][]model.ServiceInfo)
+// Group the services by the resource group (services without group are listed last)
+func listServicesGroups(services []*model.ServiceInfo) []*htmlGroup {
+	groups := make([]*htmlGroup, 0)
+	var general *htmlGroup
 	for _, service := range services {
-		if len(service.Group) > 0 {
-			groups[service.Group] = append(groups[service.Group], service)
-		} else {
-			groups[""] = append(groups[""], service)
+		var group *htmlGroup
+		for _, g := range groups {
+			if g.Name == service.Group {
+				group = g
+			}
+		}
+		if len(service.Group) == 0 {
+			if general == nil {
+				general = &htmlGroup{Name: "General"}
+			}
+			group = general
+		} else if group == nil {
+			group = &htmlGroup{Name: service.Group}
+			groups = append(groups, group)
 		}
+		group.Services = append(group.Services, service)
+	}
+	if general != nil {
+		groups = append(groups, general)
 	}
 	return groups
 }
 
-func removeSpaces(str string) string {
-	return strings.Replace(str, " ", "_", -1)
-}
-
-// Generate resources page
-func (p *HtmlProcessor) generateResources(services []model.ServiceInfo) {
-	if len(services) == 0 {
-		return
-	}
-
-	// sort services
-	sort.Slice(services, func(i, j int) bool {
-		return services[i].Name < services[j].Name
-	})
-
-	funcMap := template.FuncMap{
-		"listServiceMethods":  listServiceMethods,
-		"listPathMethodTypes": listPathMethodTypes,
-		"listServicesGroups":  listServicesGroups,
-		"removeSpaces":        removeSpaces,
-	}
-	tmpl, _ := template.New("base.html").Funcs(funcMap).ParseFiles("templates/html/base.html", "templates/html/index.html", "templates/html/footer.html")
-	f, err := os.Create("./output/html/index.html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if err := tmpl.Execute(f, services); err != nil {
-		fmt.Println("Error generating: ", err)
-		return
+// Build type expression where every documented class or enum links to its page (e.g. EntityResponse<User>)
+func (p *HtmlProcessor) typeLink(typeName string) string {
+	output := ""
+	last := 0
+	for _, loc := range htmlIdentifier.FindAllStringIndex(typeName, -1) {
+		output += html.EscapeString(typeName[last:loc[0]])
+		name := typeName[loc[0]:loc[1]]
+		if p.isDocumented(name) {
+			output += fmt.Sprintf(`<a href="json_%s.html">%s</a>`, name, name)
+		} else {
+			output += html.EscapeString(name)
+		}
+		last = loc[1]
 	}
+	return output + html.EscapeString(typeName[last:])
 }
 
-// Generate web sockets page
-func (p *HtmlProcessor) generateWebSocketsPage(sockets []model.WebSocketInfo) {
-	if len(sockets) == 0 {
-		return
-	}
-
-	tmpl, _ := template.New("base.html").ParseFiles("templates/html/footer.html", "templates/html/web_sockets.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/webSockets.html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if err := tmpl.Execute(f, sockets); err != nil {
-		fmt.Println("Error generateWebSocketsPage: ", err)
-		return
+// Check if type has documentation page
+func (p *HtmlProcessor) isDocumented(name string) bool {
+	for _, class := range p.classes {
+		if class.Name == name {
+			return true
+		}
 	}
-}
-
-func contains(classes []model.ClassInfo, className string) bool {
-	for _, c := range classes {
-		if c.Name == className {
+	for _, enum := range p.enums {
+		if enum.Name == name {
 			return true
 		}
 	}
 	return false
 }
 
-func addBodyParam(bodyParam *model.ParamInfo) string {
-	rows := ""
-	dataTypeRef := ""
-	arrayPrefix := ""
-	if bodyParam != nil {
-		if bodyParam.IsArray {
-			arrayPrefix = "array of "
-		}
-		if contains(classes, bodyParam.Name) {
-			dataTypeRef = fmt.Sprintf(`%s<a href="json_%s.html">%s</a> (JSON)`, arrayPrefix, bodyParam.Name, bodyParam.Name)
-		} else {
-			dataTypeRef = fmt.Sprintf(`%s<a href="json_%s.html">%s</a> (JSON)`, arrayPrefix, bodyParam.Type, bodyParam.Type)
-		}
-		rows += fmt.Sprintf(
-			`
-			<tr>
-				<td><abbr data-toggle="tooltip" data-placement="top"
-				title="Use the &quot;Content-Type: application/json&quot; HTTP header to specify this media type to the server."><span
-				class="request-type">application/json</span></abbr></td>
-				<td><span class="datatype-reference">%s</span></td>
-				<td><span class="request-description">%s</span></td>
-			</tr>
-			 `,
-			dataTypeRef,
-			strings.Join(bodyParam.Docs, "<br>"),
-		)
-	}
-	return "<tr>" + rows + "</tr>"
+// Build the class field type with links
+func (p *HtmlProcessor) fieldType(field *model.FieldInfo) string {
+	return p.typeLink(fieldGoType(field))
 }
 
-func listServiceMethods(service model.ServiceInfo) string {
-	methods := make(map[string]string)
-	output := ""
-	for _, method := range service.Methods {
-		methods[method.Path] = method.Path
+// Build the method parameter type with links
+func (p *HtmlProcessor) paramType(param *model.ParamInfo) string {
+	if param.IsArray && !strings.HasPrefix(param.Type, "[]") {
+		return p.typeLink("[]" + param.Type)
 	}
-	for _, method := range methods {
-		output += fmt.Sprintf(`
-		<li>
-			<samp>
-				<span class="resource-path">%s%s</span>
-			</samp>
-		</li>
-		`,
-			service.Path,
-			method,
-		)
-	}
-	return output
+	return p.typeLink(param.Type)
 }
 
-func listPathMethodTypes(service model.ServiceInfo) string {
-	methods := make(map[string][]string)
-	output := ""
-	for _, method := range service.Methods {
-		methods[method.Path] = append(methods[method.Path],
-			fmt.Sprintf(`<span class="label label-default resource-method">%s</span>`,
-				method.Method),
-		)
-	}
-
-	for _, method := range methods {
-		output += fmt.Sprintf(`
-		<li>
-			<samp>
-				%s
-			</samp>
-		</li>
-		`,
-			strings.Join(method, "&nbsp;"),
-		)
+// Build the method return type with links
+func (p *HtmlProcessor) returnType(mi *model.MethodInfo) string {
+	if mi.ReturnType == nil {
+		return "void"
 	}
-	return output
+	return p.typeLink(mi.ReturnType.String())
 }
 
-func addParams(params []*model.ParamInfo) string {
-
-	rows := ""
-	for _, param := range params {
-		rows += fmt.Sprintf(
-			`
-			<tr>
-				<td><span class="parameter-name"> %s </span></td>
-				<td>%s</td>
-				<td><span class="parameter-description">%s</span></td>
-			</tr>`,
-			param.TsName,
-			param.ParamType,
-			strings.Join(param.Docs, "<br>"))
-	}
-	return rows
+// Build JSON example of the method body parameter for the API explorer
+func (p *HtmlProcessor) bodySample(param *model.ParamInfo) string {
+	return html.EscapeString(sampleBody(p.Model, param))
 }
 
-// Generate enums table
-func (p *HtmlProcessor) generateEnumsTable(enums []model.EnumInfo) {
-	if len(enums) == 0 {
-		return
-	}
-
-	// sort enums
-	sort.Slice(enums, func(i, j int) bool {
-		return enums[i].Name < enums[j].Name
-	})
-
-	tmpl, _ := template.New("base.html").ParseFiles("templates/html/footer.html", "templates/html/enums.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/enums.html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if err := tmpl.Execute(f, enums); err != nil {
-		fmt.Println("Error generateEnumsTable: ", err)
-		return
+// Get the @Example of path or query parameter to fill the API explorer input (empty when not set)
+func (p *HtmlProcessor) paramExample(param *model.ParamInfo) string {
+	if len(param.Example) == 0 {
+		return ""
 	}
+	return sampleParam(p.Model, param)
 }
 
-// Generate classes table
-func (p *HtmlProcessor) generateClassesTable(classes []model.ClassInfo) {
-	if len(classes) == 0 {
-		return
+// List the HTTP headers of the method (service headers first)
+func htmlMethodHeaders(service *model.ServiceInfo, mi *model.MethodInfo) []string {
+	list := make([]string, 0)
+	for _, header := range append(append(make([]string, 0), service.Headers...), mi.Headers...) {
+		if !slices.Contains(list, header) {
+			list = append(list, header)
+		}
 	}
+	return list
+}
 
-	// sort classes
-	sort.Slice(classes, func(i, j int) bool {
-		return classes[i].Name < classes[j].Name
-	})
-
-	tmpl, _ := template.New("base.html").ParseFiles("templates/html/footer.html", "templates/html/data_types.html", "templates/html/base.html")
-	f, err := os.Create("./output/html/dataTypes.html")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
-	if err := tmpl.Execute(f, classes); err != nil {
-		fmt.Println("Error generating: ", err)
-		return
+// Build HTML text from documentation lines (lines are separated by line break)
+func htmlDocs(docs []string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
 	}
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
 }
 
-func getType(pType string) string {
-	types := map[string]string{
-		"double":   "number",
-		"float":    "number",
-		"int32":    "number",
-		"int64":    "number",
-		"uint32":   "number",
-		"uint64":   "number",
-		"sint32":   "number",
-		"sint64":   "number",
-		"fixed32":  "number",
-		"fixed64":  "number",
-		"sfixed32": "number",
-		"sfixed64": "number",
-		"bool":     "boolean",
-		"string":   "string",
-		"bytes":    "string",
+// Build deprecation badge of deprecated element (the reason is shown as tooltip)
+func deprecatedBadge(deprecated *model.DeprecationInfo) string {
+	if deprecated == nil {
+		return ""
 	}
+	return fmt.Sprintf(`<span class="label label-warning deprecated" title="%s">deprecated</span>`, html.EscapeString(deprecated.String()))
+}
 
-	if _, ok := types[pType]; ok {
-		return types[pType]
-	}
-	return "<a href='json_" + pType + ".html'>" + pType + "</a>"
+func removeSpaces(str string) string {
+	return strings.Replace(str, " ", "_", -1)
 }
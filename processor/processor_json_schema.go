@@ -0,0 +1,289 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var jsonSchemaTypes = map[string]string{
+	"double":    "number",
+	"float":     "number",
+	"float32":   "number",
+	"float64":   "number",
+	"int":       "integer",
+	"int32":     "integer",
+	"int64":     "integer",
+	"uint":      "integer",
+	"uint32":    "integer",
+	"uint64":    "integer",
+	"sint":      "integer",
+	"sint32":    "integer",
+	"sint64":    "integer",
+	"fixed32":   "integer",
+	"fixed64":   "integer",
+	"sfixed32":  "integer",
+	"sfixed64":  "integer",
+	"bool":      "boolean",
+	"string":    "string",
+	"bytes":     "string",
+	"Timestamp": "integer",
+	"Json":      "object",
+	"number":    "number",
+	"boolean":   "boolean",
+}
+
+// jsonSchemaFormats maps the field @Format to JSON Schema string format (other formats are display hints only)
+var jsonSchemaFormats = map[string]string{
+	"date-time": "date-time",
+	"datetime":  "date-time",
+	"date":      "date",
+	"time":      "time",
+	"duration":  "duration",
+	"email":     "email",
+	"hostname":  "hostname",
+	"ipv4":      "ipv4",
+	"ipv6":      "ipv6",
+	"uri":       "uri",
+	"url":       "uri",
+	"uuid":      "uuid",
+}
+
+// JsonSchemaProcessor - JSON Schema processor converts classes and enums to JSON Schema (draft 2020-12) files
+type JsonSchemaProcessor struct {
+	BaseProcessor
+}
+
+// NewJsonSchemaProcessor - Factory method
+func NewJsonSchemaProcessor(model *model.MetaModel, output string) *JsonSchemaProcessor {
+	return &JsonSchemaProcessor{BaseProcessor{
+		Output: output,
+		Model:  model,
+	}}
+}
+
+// Start the processor
+func (p *JsonSchemaProcessor) Start() error {
+
+	defs := make(map[string]any)
+
+	// Generate all enums
+	for _, enum := range p.Model.ListEnums() {
+		schema := p.enumSchema(enum)
+		defs[enum.Name] = schema
+		if err := p.writeSchema(enum.Name, schema); err != nil {
+			return err
+		}
+	}
+
+	// Generate all classes
+	for _, class := range p.Model.ListClasses() {
+		if class.IsParam {
+			continue
+		}
+		schema := p.classSchema(class)
+		defs[class.Name] = schema
+		if err := p.writeSchema(class.Name, schema); err != nil {
+			return err
+		}
+	}
+
+	// Generate the bundle, embedded schemas keep their $id so references are resolved in the bundle
+	bundle := map[string]any{
+		"$schema": jsonSchemaDialect,
+		"$id":     "defs.schema.json",
+		"$defs":   defs,
+	}
+	return p.writeJson(path.Join(p.Output, "defs.schema.json"), bundle)
+}
+
+// Write single schema document
+func (p *JsonSchemaProcessor) writeSchema(name string, schema map[string]any) error {
+	doc := map[string]any{"$schema": jsonSchemaDialect}
+	for k, v := range schema {
+		doc[k] = v
+	}
+	return p.writeJson(path.Join(p.Output, schemaFileName(name)), doc)
+}
+
+// Write any object as indented JSON
+func (p *JsonSchemaProcessor) writeJson(fileName string, doc any) error {
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling schema %s: %s", fileName, err.Error())
+	}
+	return p.writeFile(fileName, string(bytes))
+}
+
+// Build enum schema, every enum value is a const with its name as a title
+func (p *JsonSchemaProcessor) enumSchema(enum *model.EnumInfo) map[string]any {
+	values := make([]any, 0)
+	for _, ev := range enum.Values {
+		value := map[string]any{
+			"const": ev.Value,
+			"title": ev.Name,
+		}
+		if docs := joinDocs(ev.Docs); len(docs) > 0 {
+			value["description"] = docs
+		}
+		setSchemaDeprecated(value, ev.Deprecated)
+		values = append(values, value)
+	}
+
+	schema := map[string]any{
+		"$id":   schemaFileName(enum.Name),
+		"title": enum.Name,
+		"type":  "integer",
+	}
+	if len(values) > 0 {
+		schema["oneOf"] = values
+	}
+	if docs := joinDocs(enum.Docs); len(docs) > 0 {
+		schema["description"] = docs
+	}
+	setSchemaDeprecated(schema, enum.Deprecated)
+	return schema
+}
+
+// Build class schema, generic type parameters are declared as dynamic anchors to be bound by the referencing schema
+func (p *JsonSchemaProcessor) classSchema(class *model.ClassInfo) map[string]any {
+	properties := make(map[string]any)
+	for _, field := range class.Fields {
+		properties[field.Json] = p.fieldSchema(class, field)
+	}
+
+	schema := map[string]any{
+		"$id":        schemaFileName(class.Name),
+		"title":      class.Name,
+		"type":       "object",
+		"properties": properties,
+	}
+	if docs := joinDocs(class.Docs); len(docs) > 0 {
+		schema["description"] = docs
+	}
+	setSchemaDeprecated(schema, class.Deprecated)
+
+	if class.IsGeneric && len(class.GenericTypes) > 0 {
+		anchors := make(map[string]any)
+		for _, gt := range class.GenericTypes {
+			anchors[gt.Key] = map[string]any{"$dynamicAnchor": gt.Key}
+		}
+		schema["$defs"] = anchors
+	}
+
+	if len(class.BaseClass) > 0 && p.Model.GetClass(class.BaseClass) != nil {
+		schema["allOf"] = []any{map[string]any{"$ref": schemaFileName(class.BaseClass)}}
+	}
+	return schema
+}
+
+// Build field schema
+func (p *JsonSchemaProcessor) fieldSchema(class *model.ClassInfo, field *model.FieldInfo) map[string]any {
+	var schema map[string]any
+
+	node := model.NewTypeNodeFromGoType(field.Type)
+	if node == nil {
+		schema = make(map[string]any)
+	} else {
+		schema = p.typeSchema(class, node, fmt.Sprintf("%s.%s", class.Name, field.Json))
+	}
+
+	if format, ok := jsonSchemaFormats[strings.ToLower(field.Format)]; ok && schema["type"] == "string" {
+		schema["format"] = format
+	}
+	if field.Type == "bytes" {
+		schema["contentEncoding"] = "base64"
+	}
+
+	if field.IsArray {
+		schema = map[string]any{
+			"type":  "array",
+			"items": schema,
+		}
+	}
+	if docs := joinDocs(field.Docs); len(docs) > 0 {
+		schema["description"] = docs
+	}
+	if len(field.Example) > 0 {
+		schema["examples"] = []any{json.RawMessage(exampleLiteral(field.Example, fieldGoType(field)))}
+	}
+	setSchemaDeprecated(schema, field.Deprecated)
+	return schema
+}
+
+// Build schema of a type node, generic arguments are bound to the dynamic anchors of the referenced class
+func (p *JsonSchemaProcessor) typeSchema(class *model.ClassInfo, node *model.TypeNode, id string) map[string]any {
+	schema := p.namedTypeSchema(class, node.Name)
+
+	if len(node.Args) > 0 {
+		if generic := p.Model.GetClass(node.Name); generic != nil {
+			bindings := make(map[string]any)
+			for i, arg := range node.Args {
+				if i >= len(generic.GenericTypes) {
+					break
+				}
+				key := generic.GenericTypes[i].Key
+				binding := p.typeSchema(class, arg, fmt.Sprintf("%s.%s", id, key))
+				binding["$dynamicAnchor"] = key
+				bindings[key] = binding
+			}
+			if len(bindings) > 0 {
+				schema["$id"] = schemaFileName(id)
+				schema["$defs"] = bindings
+			}
+		}
+	}
+
+	if node.IsArray {
+		schema = map[string]any{
+			"type":  "array",
+			"items": schema,
+		}
+	}
+	return schema
+}
+
+// Build schema of a type by name: primitive, enum, class or generic type parameter
+func (p *JsonSchemaProcessor) namedTypeSchema(class *model.ClassInfo, name string) map[string]any {
+	if jsType, ok := jsonSchemaTypes[name]; ok {
+		return map[string]any{"type": jsType}
+	}
+	for _, gt := range class.GenericTypes {
+		if gt.Key == name {
+			return map[string]any{"$dynamicRef": "#" + name}
+		}
+	}
+	if p.Model.GetEnum(name) != nil || p.Model.GetClass(name) != nil {
+		return map[string]any{"$ref": schemaFileName(name)}
+	}
+
+	// Unknown type (any)
+	return make(map[string]any)
+}
+
+// Mark schema as deprecated and add the deprecation message to its description
+func setSchemaDeprecated(schema map[string]any, deprecated *model.DeprecationInfo) {
+	if deprecated == nil {
+		return
+	}
+	schema["deprecated"] = true
+	if docs, ok := schema["description"].(string); ok {
+		schema["description"] = fmt.Sprintf("%s\n\nDeprecated: %s", docs, deprecated)
+	} else {
+		schema["description"] = fmt.Sprintf("Deprecated: %s", deprecated)
+	}
+}
+
+func schemaFileName(name string) string {
+	return fmt.Sprintf("%s.schema.json", name)
+}
+
+// join documentation lines to a single text
+func joinDocs(docs []string) string {
+	return strings.TrimSpace(strings.Join(docs, "\n"))
+}
@@ -0,0 +1,377 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var kotlinTypes = map[string]string{
+	"double":        "Double",
+	"float":         "Double",
+	"float32":       "Float",
+	"float64":       "Double",
+	"int":           "Int",
+	"int32":         "Int",
+	"int64":         "Long",
+	"uint":          "Long",
+	"uint32":        "Long",
+	"uint64":        "Long",
+	"sint":          "Int",
+	"sint32":        "Int",
+	"sint64":        "Long",
+	"fixed32":       "Int",
+	"fixed64":       "Long",
+	"sfixed32":      "Int",
+	"sfixed64":      "Long",
+	"bool":          "Boolean",
+	"string":        "String",
+	"bytes":         "String",
+	"any":           "JsonElement",
+	"Timestamp":     "Long",
+	"Json":          "JsonObject",
+	"StreamContent": "ResponseBody",
+	"number":        "Double",
+	"boolean":       "Boolean",
+}
+
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true, "false": true,
+	"for": true, "fun": true, "if": true, "in": true, "interface": true, "is": true, "null": true,
+	"object": true, "package": true, "return": true, "super": true, "this": true, "throw": true,
+	"true": true, "try": true, "typealias": true, "typeof": true, "val": true, "var": true, "when": true,
+	"while": true,
+}
+
+// KotlinProcessor - Kotlin processor converts the meta model to Kotlin data classes (kotlinx.serialization) and Retrofit interfaces
+type KotlinProcessor struct {
+	BaseProcessor
+	Package string // Root Kotlin package name (e.g. com.example.api)
+}
+
+// NewKotlinProcessor - Factory method
+func NewKotlinProcessor(model *model.MetaModel, output string, pkg string) *KotlinProcessor {
+	return &KotlinProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Package: pkg,
+	}
+}
+
+// kotlinFile is the template data of a single Kotlin file
+type kotlinFile struct {
+	Package string
+	Item    any
+}
+
+// Start the processor
+func (p *KotlinProcessor) Start() error {
+
+	funcMap := template.FuncMap{
+		"ktDocs":         ktDocs,
+		"ktDeprecated":   ktDeprecated,
+		"ktName":         ktName,
+		"ktQuote":        strconv.Quote,
+		"ktGenerics":     ktGenerics,
+		"ktClassFields":  p.ktClassFields,
+		"ktFieldType":    p.ktFieldType,
+		"ktMethodParams": p.ktMethodParams,
+		"ktReturnType":   p.ktReturnType,
+		"ktMethodPath":   ktMethodPath,
+		"toCamelCase":    toCamelCase,
+		"toUpperCase":    strings.ToUpper,
+		"join":           strings.Join,
+		"last":           func(i int, n int) bool { return i == n-1 },
+	}
+
+	folder := path.Join(p.Output, strings.ReplaceAll(p.Package, ".", "/"))
+
+	// Generate all enums
+	for _, enum := range p.Model.ListEnums() {
+		fileName := path.Join(folder, "model", fmt.Sprintf("%s.kt", enum.Name))
+		if err := p.generate(fileName, kotlinEnumTemplate, funcMap, enum); err != nil {
+			return err
+		}
+	}
+
+	// Generate all classes
+	for _, class := range p.Model.ListClasses() {
+		if class.IsParam {
+			continue
+		}
+		fileName := path.Join(folder, "model", fmt.Sprintf("%s.kt", class.Name))
+		if err := p.generate(fileName, kotlinClassTemplate, funcMap, class); err != nil {
+			return err
+		}
+	}
+
+	// Generate all services
+	services := p.Model.ListServices()
+	if len(services) > 0 {
+		fileName := path.Join(folder, "api", "QueryList.kt")
+		if err := p.generate(fileName, kotlinQueryListTemplate, funcMap, nil); err != nil {
+			return err
+		}
+	}
+	for _, service := range services {
+		fileName := path.Join(folder, "api", fmt.Sprintf("%s.kt", service.TsName))
+		if err := p.generate(fileName, kotlinServiceTemplate, funcMap, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute template and write the result to file
+func (p *KotlinProcessor) generate(fileName, source string, funcMap template.FuncMap, item any) error {
+	name := path.Base(fileName)
+	tmpl, err := template.New(name).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", name, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, kotlinFile{Package: p.Package, Item: item}); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", name, err.Error())
+	}
+	return p.writeFile(fileName, p.trimNewLines(tpl.String()))
+}
+
+// List class fields, data classes can't be extended so the base class fields are flattened
+func (p *KotlinProcessor) ktClassFields(class *model.ClassInfo) []*model.FieldInfo {
+	return p.Model.ListClassFields(class)
+}
+
+// Get the Kotlin type of class field
+func (p *KotlinProcessor) ktFieldType(class *model.ClassInfo, field *model.FieldInfo) string {
+	ktType := p.ktType(model.NewTypeNodeFromGoType(field.Type), class.GenericTypes)
+	if field.IsArray {
+		ktType = fmt.Sprintf("List<%s>", ktType)
+	}
+	return ktType
+}
+
+// Get the Kotlin type of type node
+func (p *KotlinProcessor) ktType(node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node == nil {
+		return "JsonElement"
+	}
+
+	result := "JsonElement"
+	if ktType, ok := kotlinTypes[node.Name]; ok {
+		result = ktType
+	} else if p.Model.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := p.Model.GetClass(node.Name); class != nil {
+		result = node.Name
+		if class.IsGeneric && len(node.Args) > 0 {
+			args := make([]string, 0)
+			for _, arg := range node.Args {
+				args = append(args, p.ktType(arg, generics))
+			}
+			result = fmt.Sprintf("%s<%s>", node.Name, strings.Join(args, ", "))
+		}
+	} else {
+		for _, gt := range generics {
+			if gt.Key == node.Name {
+				result = node.Name
+			}
+		}
+	}
+
+	if node.IsArray {
+		result = fmt.Sprintf("List<%s>", result)
+	}
+	return result
+}
+
+// Build method input parameters list with Retrofit annotations
+func (p *KotlinProcessor) ktMethodParams(mi *model.MethodInfo) string {
+	params := make([]string, 0)
+	for _, param := range listMethodParams(*mi) {
+		name := ktName(param.Json)
+		switch param.ParamType {
+		case "file":
+			params = append(params, fmt.Sprintf("@Part %s: MultipartBody.Part", name))
+		case "path":
+			params = append(params, fmt.Sprintf("@Path(%q) %s: %s", param.Json, name, p.ktParamType(param)))
+		case "query":
+			ktType := p.ktParamType(param)
+			if param.IsArray {
+				// list values are sent as single comma separated value (Retrofit repeats the key for List)
+				ktType = fmt.Sprintf("QueryList<%s>", p.ktType(model.NewTypeNode(param.Type), nil))
+			}
+			params = append(params, fmt.Sprintf("@Query(%q) %s: %s? = null", param.Json, name, ktType))
+		case "body":
+			if HasRequestBody(mi) {
+				params = append(params, fmt.Sprintf("@Body %s: %s", name, p.ktParamType(param)))
+			}
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// Get the Kotlin type of method parameter
+func (p *KotlinProcessor) ktParamType(param *model.ParamInfo) string {
+	ktType := p.ktType(model.NewTypeNode(param.Type), nil)
+	if param.IsArray {
+		ktType = fmt.Sprintf("List<%s>", ktType)
+	}
+	return ktType
+}
+
+// Get the method return type
+func (p *KotlinProcessor) ktReturnType(mi *model.MethodInfo) string {
+	if mi.ReturnType == nil {
+		return "Unit"
+	}
+	return p.ktType(mi.ReturnType, nil)
+}
+
+// Build the relative method path (relative to the Retrofit base URL)
+func ktMethodPath(si *model.ServiceInfo, mi *model.MethodInfo) string {
+	return strings.TrimPrefix(si.MethodPath(mi), "/")
+}
+
+// convert name to Kotlin identifier (escape reserved words)
+func ktName(name string) string {
+	result := toCamelCase(name)
+	if kotlinKeywords[result] {
+		result = fmt.Sprintf("`%s`", result)
+	}
+	return result
+}
+
+// Build generic type parameters list
+func ktGenerics(class *model.ClassInfo) string {
+	if !class.IsGeneric || len(class.GenericTypes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0)
+	for _, gt := range class.GenericTypes {
+		keys = append(keys, gt.Key)
+	}
+	return fmt.Sprintf("<%s>", strings.Join(keys, ", "))
+}
+
+// Build KDoc comment from documentation lines
+func ktDocs(docs []string, indent string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
+	}
+	text = strings.ReplaceAll(text, "*/", "*&#47;")
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return fmt.Sprintf("%s/** %s */\n", indent, text)
+	}
+	output := indent + "/**\n"
+	for _, line := range lines {
+		output += fmt.Sprintf("%s * %s\n", indent, line)
+	}
+	output += indent + " */\n"
+	return output
+}
+
+// Build Deprecated annotation of deprecated element
+func ktDeprecated(deprecated *model.DeprecationInfo, indent string) string {
+	if deprecated == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s@Deprecated(%s)\n", indent, strings.ReplaceAll(strconv.Quote(deprecated.String()), "$", "\\$"))
+}
+
+// region Kotlin templates ---------------------------------------------------------------------------------------------
+
+var kotlinEnumTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+package {{.Package}}.model
+
+import kotlinx.serialization.KSerializer
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.descriptors.PrimitiveKind
+import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor
+import kotlinx.serialization.descriptors.SerialDescriptor
+import kotlinx.serialization.encoding.Decoder
+import kotlinx.serialization.encoding.Encoder
+{{with .Item}}
+{{ktDocs .Docs ""}}{{ktDeprecated .Deprecated ""}}@Serializable(with = {{.Name}}Serializer::class)
+enum class {{.Name}}(val value: Int) {
+{{$n := len .Values}}{{range $i, $v := .Values}}{{ktDocs $v.Docs "    "}}{{ktDeprecated $v.Deprecated "    "}}    {{$v.Name}}({{$v.Value}}){{if last $i $n}};{{else}},{{end}}
+{{else}}    ;
+{{end}}
+    // Numeric value is used when the enum is sent as query or path parameter
+    override fun toString(): String = value.toString()
+
+    companion object {
+        fun fromValue(value: Int): {{.Name}}? = entries.firstOrNull { it.value == value }
+    }
+}
+
+// Serialize {{.Name}} as its numeric value
+object {{.Name}}Serializer : KSerializer<{{.Name}}> {
+    override val descriptor: SerialDescriptor = PrimitiveSerialDescriptor("{{.Name}}", PrimitiveKind.INT)
+
+    override fun serialize(encoder: Encoder, value: {{.Name}}) = encoder.encodeInt(value.value)
+
+    override fun deserialize(decoder: Decoder): {{.Name}} {
+        val value = decoder.decodeInt()
+        return {{.Name}}.fromValue(value) ?: throw IllegalArgumentException("unknown {{.Name}} value: $value")
+    }
+}
+{{end}}`
+
+var kotlinClassTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+package {{.Package}}.model
+
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.JsonElement
+import kotlinx.serialization.json.JsonObject
+{{with .Item}}{{$class := .}}
+{{ktDocs .Docs ""}}{{ktDeprecated .Deprecated ""}}@Serializable
+data class {{.Name}}{{ktGenerics .}}(
+{{range ktClassFields .}}{{ktDocs .Docs "    "}}{{ktDeprecated .Deprecated "    "}}    @SerialName({{ktQuote .Json}}) val {{ktName .Name}}: {{ktFieldType $class .}}? = null,
+{{end}})
+{{end}}`
+
+var kotlinServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+package {{.Package}}.api
+
+import {{.Package}}.model.*
+import kotlinx.serialization.json.JsonElement
+import kotlinx.serialization.json.JsonObject
+import okhttp3.MultipartBody
+import okhttp3.ResponseBody
+import retrofit2.http.*
+{{with .Item}}{{$service := .}}
+{{with .Headers}}// Expected HTTP headers (add them using OkHttp interceptor): {{join . ", "}}
+{{end}}{{ktDocs .Docs ""}}{{ktDeprecated .Deprecated ""}}interface {{.TsName}} {
+{{range .Methods}}
+{{ktDocs .Docs "    "}}{{ktDeprecated .Deprecated "    "}}{{if .FileParam}}    @Multipart
+{{end}}{{if eq .ReturnClass "StreamContent"}}    @Streaming
+{{end}}    @{{toUpperCase .Method}}({{ktMethodPath $service . | ktQuote}})
+    suspend fun {{ktName .Name}}({{ktMethodParams .}}): {{ktReturnType .}}
+{{end}}}
+{{end}}`
+
+var kotlinQueryListTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+package {{.Package}}.api
+
+// List query parameter sent as single comma separated value (e.g. status=1,2), Retrofit converts query parameters
+// using toString()
+class QueryList<T>(val items: List<T>) {
+    override fun toString(): String = items.joinToString(",")
+}
+
+// Convert list to comma separated query parameter
+fun <T> List<T>.toQueryList(): QueryList<T> = QueryList(this)
+`
+
+// endregion
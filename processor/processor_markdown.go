@@ -0,0 +1,530 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// mdEscaper escapes characters with special meaning in Markdown text and MDX (Docusaurus) pages
+var mdEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;", "{", "&#123;", "}", "&#125;")
+
+// mdTypeEscaper escapes the non identifier parts of type expression (e.g. []EntityResponse<User>)
+var mdTypeEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;", "[", `\[`, "]", `\]`, "*", `\*`)
+
+// mdIdentifier matches type names in type expression
+var mdIdentifier = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_.]*`)
+
+// MarkdownProcessor - Markdown processor converts the meta model to Markdown pages (page per service, class, enum and
+// web socket) with front-matter and sidebars for static site generators (MkDocs and Docusaurus)
+type MarkdownProcessor struct {
+	BaseProcessor
+	Prefix   string // Path of the output folder relative to the docs root folder, used in the sidebars (e.g. api)
+	classes  []*model.ClassInfo
+	enums    []*model.EnumInfo
+	services []*model.ServiceInfo
+	sockets  []*model.WebSocketInfo
+}
+
+// NewMarkdownProcessor - Factory method
+func NewMarkdownProcessor(model *model.MetaModel, output string) *MarkdownProcessor {
+	return &MarkdownProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+// WithPrefix sets the path of the output folder relative to the docs root folder (used in the sidebar document ids)
+func (p *MarkdownProcessor) WithPrefix(prefix string) *MarkdownProcessor {
+	p.Prefix = strings.Trim(prefix, "/")
+	return p
+}
+
+// mdPage is the template data of single page
+type mdPage struct {
+	Title       string
+	Description string
+	Item        any
+}
+
+// mdIndex is the template data of the overview page
+type mdIndex struct {
+	Groups  []*mdCategory
+	Classes []*model.ClassInfo
+	Enums   []*model.EnumInfo
+	Sockets []*model.WebSocketInfo
+}
+
+// mdCategory is a sidebar category (model elements of the same group)
+type mdCategory struct {
+	Label    string
+	Items    []*mdSidebarItem
+	Services []*model.ServiceInfo
+}
+
+// mdSidebarItem is a single page in the sidebar
+type mdSidebarItem struct {
+	Label string
+	Page  string // Page path relative to the output folder without extension (e.g. services/UsersService)
+}
+
+// mdUsage lists the classes and services referring to a class or enum
+type mdUsage struct {
+	Classes  []*model.ClassInfo
+	Services []*model.ServiceInfo
+}
+
+// Start the processor
+func (p *MarkdownProcessor) Start() error {
+
+	p.classes = make([]*model.ClassInfo, 0)
+	for _, class := range p.Model.ListClasses() {
+		if class.IsVisible {
+			p.classes = append(p.classes, class)
+		}
+	}
+	p.enums = p.Model.ListEnums()
+	p.services = p.Model.ListServices()
+	p.sockets = p.Model.ListWebSockets()
+
+	for _, service := range p.services {
+		if err := p.generate(path.Join("services", service.Name+".md"), markdownServiceTemplate, service.TsName, service.Docs, service); err != nil {
+			return err
+		}
+	}
+	for _, class := range p.classes {
+		if err := p.generate(path.Join("classes", class.Name+".md"), markdownClassTemplate, class.Name, class.Docs, class); err != nil {
+			return err
+		}
+	}
+	for _, enum := range p.enums {
+		if err := p.generate(path.Join("enums", enum.Name+".md"), markdownEnumTemplate, enum.Name, enum.Docs, enum); err != nil {
+			return err
+		}
+	}
+	for _, socket := range p.sockets {
+		if err := p.generate(path.Join("sockets", socket.Name+".md"), markdownSocketTemplate, socket.TsName, socket.Docs, socket); err != nil {
+			return err
+		}
+	}
+
+	categories := p.listCategories()
+	index := &mdIndex{Groups: make([]*mdCategory, 0), Classes: p.classes, Enums: p.enums, Sockets: p.sockets}
+	for _, category := range categories {
+		if len(category.Services) > 0 {
+			index.Groups = append(index.Groups, category)
+		}
+	}
+	if err := p.generate("index.md", markdownIndexTemplate, "API Reference", []string{"API resources, data types and enums"}, index); err != nil {
+		return err
+	}
+	diagrams := diagramPage{ClassDiagram: mermaidClassDiagram(p.Model, p.classes), ErDiagram: mermaidErDiagram(p.Model, listEntities(p.classes))}
+	if err := p.generate("diagrams.md", markdownDiagramsTemplate, "Data Model", []string{"Class and ER diagrams of the data model"}, diagrams); err != nil {
+		return err
+	}
+
+	// Generate the sidebars
+	if err := p.generate("sidebars.js", markdownDocusaurusSidebarTemplate, "", nil, categories); err != nil {
+		return err
+	}
+	return p.generate("mkdocs.nav.yml", markdownMkDocsNavTemplate, "", nil, categories)
+}
+
+// Execute the page template and write the output file
+func (p *MarkdownProcessor) generate(fileName string, source string, title string, docs []string, item any) error {
+	// links are relative to the page folder
+	base := strings.Repeat("../", strings.Count(fileName, "/"))
+
+	funcMap := template.FuncMap{
+		"text":              mdText,
+		"cell":              mdCell,
+		"deprecation":       func(d *model.DeprecationInfo) string { return mdEscaper.Replace(d.String()) },
+		"yaml":              strconv.Quote,
+		"docId":             p.docId,
+		"typeLink":          func(typeName string) string { return p.typeLink(base, typeName) },
+		"fieldType":         func(field *model.FieldInfo) string { return p.typeLink(base, fieldGoType(field)) },
+		"paramType":         func(param *model.ParamInfo) string { return p.typeLink(base, p.paramType(param)) },
+		"returnType":        func(mi *model.MethodInfo) string { return p.returnType(base, mi) },
+		"usage":             p.usage,
+		"listClassFields":   p.Model.ListClassFields,
+		"listMethodParams":  func(mi *model.MethodInfo) []*model.ParamInfo { return listMethodParams(*mi) },
+		"methodDeprecation": methodDeprecation,
+		"methodRoute":       (*model.ServiceInfo).MethodPath,
+		"anchor":            strings.ToLower,
+		"last":              func(i int, n int) bool { return i == n-1 },
+	}
+
+	tmpl, err := template.New(fileName).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", fileName, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	data := mdPage{Title: title, Description: docsLine(docs), Item: item}
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", fileName, err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, fileName), p.trimNewLines(tpl.String()))
+}
+
+// List the sidebar categories: the named groups (sorted by name) followed by the elements without group
+func (p *MarkdownProcessor) listCategories() []*mdCategory {
+	named := make(map[string]*mdCategory)
+	defaults := []*mdCategory{{Label: "General"}, {Label: "Data Types"}, {Label: "Enums"}, {Label: "Web Sockets"}}
+
+	add := func(group string, fallback int, label string, page string) *mdCategory {
+		category := defaults[fallback]
+		if len(group) > 0 {
+			if category = named[group]; category == nil {
+				category = &mdCategory{Label: group}
+				named[group] = category
+			}
+		}
+		category.Items = append(category.Items, &mdSidebarItem{Label: label, Page: page})
+		return category
+	}
+
+	for _, service := range p.services {
+		category := add(service.Group, 0, service.TsName, "services/"+service.Name)
+		category.Services = append(category.Services, service)
+	}
+	for _, class := range p.classes {
+		add(class.Group, 1, class.Name, "classes/"+class.Name)
+	}
+	for _, enum := range p.enums {
+		add(enum.Group, 2, enum.Name, "enums/"+enum.Name)
+	}
+	for _, socket := range p.sockets {
+		add(socket.Group, 3, socket.TsName, "sockets/"+socket.Name)
+	}
+
+	result := make([]*mdCategory, 0)
+	for _, category := range named {
+		result = append(result, category)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Label < result[j].Label
+	})
+	for _, category := range defaults {
+		if len(category.Items) > 0 {
+			result = append(result, category)
+		}
+	}
+	return result
+}
+
+// Get the document id of page (page path relative to the docs root folder)
+func (p *MarkdownProcessor) docId(page string) string {
+	if len(p.Prefix) == 0 {
+		return page
+	}
+	return p.Prefix + "/" + page
+}
+
+// Build type expression where every documented class or enum links to its page
+func (p *MarkdownProcessor) typeLink(base string, typeName string) string {
+	output := ""
+	last := 0
+	for _, loc := range mdIdentifier.FindAllStringIndex(typeName, -1) {
+		output += mdTypeEscaper.Replace(typeName[last:loc[0]])
+		name := typeName[loc[0]:loc[1]]
+		if page := p.typePage(name); len(page) > 0 {
+			output += fmt.Sprintf("[%s](%s%s.md)", name, base, page)
+		} else {
+			output += name
+		}
+		last = loc[1]
+	}
+	return output + mdTypeEscaper.Replace(typeName[last:])
+}
+
+// Get the page of documented class or enum (empty if the type is not documented)
+func (p *MarkdownProcessor) typePage(name string) string {
+	for _, class := range p.classes {
+		if class.Name == name {
+			return "classes/" + name
+		}
+	}
+	for _, enum := range p.enums {
+		if enum.Name == name {
+			return "enums/" + name
+		}
+	}
+	return ""
+}
+
+// Get the method parameter type
+func (p *MarkdownProcessor) paramType(param *model.ParamInfo) string {
+	if param.IsArray && !strings.HasPrefix(param.Type, "[]") {
+		return "[]" + param.Type
+	}
+	return param.Type
+}
+
+// Get the method return type with links
+func (p *MarkdownProcessor) returnType(base string, mi *model.MethodInfo) string {
+	if mi.ReturnType == nil {
+		return "void"
+	}
+	return p.typeLink(base, mi.ReturnType.String())
+}
+
+// List the classes and services referring to the type (by field, base class, parameter or return type)
+func (p *MarkdownProcessor) usage(name string) *mdUsage {
+	result := &mdUsage{}
+	for _, class := range p.classes {
+		types := []string{class.BaseClass}
+		for _, field := range class.Fields {
+			types = append(types, field.Type)
+		}
+		if class.Name != name && mdRefersTo(types, name) {
+			result.Classes = append(result.Classes, class)
+		}
+	}
+	for _, service := range p.services {
+		types := make([]string, 0)
+		for _, mi := range service.Methods {
+			for _, param := range listMethodParams(*mi) {
+				types = append(types, param.Type)
+			}
+			if mi.ReturnType != nil {
+				types = append(types, mi.ReturnType.String())
+			}
+		}
+		if mdRefersTo(types, name) {
+			result.Services = append(result.Services, service)
+		}
+	}
+	return result
+}
+
+// Check if any of the type expressions refers to the type name
+func mdRefersTo(types []string, name string) bool {
+	for _, typeName := range types {
+		for _, identifier := range mdIdentifier.FindAllString(typeName, -1) {
+			if identifier == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Escape documentation text
+func mdText(docs []string) string {
+	return mdEscaper.Replace(joinDocs(docs))
+}
+
+// Escape documentation text for table cell (single line)
+func mdCell(docs []string) string {
+	text := strings.ReplaceAll(mdText(docs), "|", `\|`)
+	return strings.ReplaceAll(text, "\n", "<br/>")
+}
+
+// region Markdown templates -------------------------------------------------------------------------------------------
+
+var markdownFrontMatter = `---
+title: {{yaml .Title}}
+sidebar_label: {{yaml .Title}}
+{{- with .Description}}
+description: {{yaml .}}
+{{- end}}
+---
+`
+
+var markdownIndexTemplate = markdownFrontMatter + `
+# API Reference
+{{with .Item}}
+{{range .Groups}}
+## {{.Label}}
+
+| Resource | Endpoints | Description |
+|----------|-----------|-------------|
+{{range .Services}}{{$service := .}}| [{{.TsName}}](services/{{.Name}}.md){{if .Deprecated}} *(deprecated)*{{end}} | {{range $i, $m := .Methods}}{{if $i}}<br/>{{end}}[` + "`{{$m.Method}} {{methodRoute $service $m}}`" + `](services/{{$service.Name}}.md#{{anchor $m.Name}}){{end}} | {{cell .Docs}} |
+{{end}}{{end}}
+{{with .Classes}}
+## Data Types
+
+See the [data model diagrams](diagrams.md).
+
+| Type | Description |
+|------|-------------|
+{{range .}}| [{{.Name}}](classes/{{.Name}}.md){{if .Deprecated}} *(deprecated)*{{end}} | {{cell .Docs}} |
+{{end}}{{end}}
+{{with .Enums}}
+## Enums
+
+| Enum | Description |
+|------|-------------|
+{{range .}}| [{{.Name}}](enums/{{.Name}}.md){{if .Deprecated}} *(deprecated)*{{end}} | {{cell .Docs}} |
+{{end}}{{end}}
+{{with .Sockets}}
+## Web Sockets
+
+| Web Socket | Path | Description |
+|------------|------|-------------|
+{{range .}}| [{{.TsName}}](sockets/{{.Name}}.md) | ` + "`{{.Path}}`" + ` | {{cell .Docs}} |
+{{end}}{{end}}
+{{end}}`
+
+var markdownDiagramsTemplate = markdownFrontMatter + `{{with .Item}}
+# Data Model
+
+## Class Diagram
+
+` + "```mermaid\n{{.ClassDiagram}}```" + `
+
+## ER Diagram
+
+` + "```mermaid\n{{.ErDiagram}}```" + `
+{{end}}`
+
+var markdownServiceTemplate = markdownFrontMatter + `{{with .Item}}{{$service := .}}
+# {{.TsName}}
+
+{{text .Docs}}
+{{with .Deprecated}}
+> **Deprecated:** {{deprecation .}}
+{{end}}
+**Path:** ` + "`{{.Path}}`" + `
+{{with .Headers}}
+**HTTP headers:** {{range $i, $h := .}}{{if $i}}, {{end}}` + "`{{$h}}`" + `{{end}}
+{{end}}
+## Endpoints
+
+| Method | Path | Description |
+|--------|------|-------------|
+{{range .Methods}}| ` + "`{{.Method}}`" + ` | [` + "`{{methodRoute $service .}}`" + `](#{{anchor .Name}}){{if methodDeprecation $service .}} *(deprecated)*{{end}} | {{cell .Docs}} |
+{{end}}
+{{range .Methods}}
+## {{.Name}}
+
+` + "`{{.Method}} {{methodRoute $service .}}`" + `
+
+{{text .Docs}}
+{{with methodDeprecation $service .}}
+> **Deprecated:** {{deprecation .}}
+{{end}}
+{{with listMethodParams .}}
+### Parameters
+
+| Name | In | Type | Description |
+|------|----|------|-------------|
+{{range .}}| ` + "`{{.Json}}`" + ` | {{.ParamType}} | {{paramType .}} | {{cell .Docs}} |
+{{end}}{{end}}
+{{with .Headers}}
+### Headers
+
+{{range .}}- ` + "`{{.}}`" + `
+{{end}}{{end}}
+### Response
+
+{{returnType .}}
+{{end}}{{end}}`
+
+var markdownClassTemplate = markdownFrontMatter + `{{with .Item}}
+# {{.Name}}
+
+{{text .Docs}}
+{{with .Deprecated}}
+> **Deprecated:** {{deprecation .}}
+{{end}}
+{{with .BaseClass}}
+Extends {{typeLink .}}
+{{end}}
+{{with .GenericTypes}}
+Type parameters: {{range $i, $g := .}}{{if $i}}, {{end}}` + "`{{$g.Key}}`" + `{{end}}
+{{end}}
+## Properties
+
+| Name | Type | Description |
+|------|------|-------------|
+{{range $f := listClassFields .}}| ` + "`{{.Json}}`" + `{{if .Deprecated}} *(deprecated)*{{end}} | {{fieldType .}} | {{cell .Docs}}{{with .Deprecated}}{{if $f.Docs}}<br/>{{end}}**Deprecated:** {{deprecation .}}{{end}} |
+{{end}}
+{{with usage .Name}}{{if or .Classes .Services}}
+## Used by
+
+{{range .Classes}}- [{{.Name}}](../classes/{{.Name}}.md)
+{{end}}{{range .Services}}- [{{.TsName}}](../services/{{.Name}}.md)
+{{end}}{{end}}{{end}}
+{{end}}`
+
+var markdownEnumTemplate = markdownFrontMatter + `{{with .Item}}
+# {{.Name}}
+
+{{text .Docs}}
+{{with .Deprecated}}
+> **Deprecated:** {{deprecation .}}
+{{end}}
+## Values
+
+| Name | Value | Description |
+|------|-------|-------------|
+{{range .Values}}| ` + "`{{.Name}}`" + `{{if .Deprecated}} *(deprecated)*{{end}} | {{.Value}} | {{cell .Docs}} |
+{{end}}
+{{with usage .Name}}{{if or .Classes .Services}}
+## Used by
+
+{{range .Classes}}- [{{.Name}}](../classes/{{.Name}}.md)
+{{end}}{{range .Services}}- [{{.TsName}}](../services/{{.Name}}.md)
+{{end}}{{end}}{{end}}
+{{end}}`
+
+var markdownSocketTemplate = markdownFrontMatter + `{{with .Item}}
+# {{.TsName}}
+
+{{text .Docs}}
+
+**Path:** ` + "`{{.Path}}`" + `
+{{with .Usage}}
+## Usage
+
+` + "```" + `
+{{.}}
+` + "```" + `
+{{end}}
+## Messages
+
+| Message | Type | Payload | Description |
+|---------|------|---------|-------------|
+{{range .Methods}}| ` + "`{{.Name}}`" + ` | {{.SocketMessageType}} | {{with .BodyParam}}{{paramType .}}{{else}}{{returnType .}}{{end}} | {{cell .Docs}} |
+{{end}}{{end}}`
+
+var markdownDocusaurusSidebarTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+module.exports = {
+  apiSidebar: [
+    {{docId "index" | yaml}},
+{{- range .Item}}
+    {
+      type: "category",
+      label: {{yaml .Label}},
+      items: [{{$n := len .Items}}{{range $i, $item := .Items}}{{docId $item.Page | yaml}}{{if not (last $i $n)}}, {{end}}{{end}}],
+    },
+{{- end}}
+    {{docId "diagrams" | yaml}},
+  ],
+};
+`
+
+var markdownMkDocsNavTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+nav:
+  - "Overview": {{printf "%s.md" (docId "index") | yaml}}
+{{- range .Item}}
+  - {{yaml .Label}}:
+{{- range .Items}}
+      - {{yaml .Label}}: {{printf "%s.md" (docId .Page) | yaml}}
+{{- end}}
+{{- end}}
+  - "Data Model": {{printf "%s.md" (docId "diagrams") | yaml}}
+`
+
+// endregion
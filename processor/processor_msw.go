@@ -0,0 +1,341 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// mswPathParam matches path parameter in method path template (e.g. {id})
+var mswPathParam = regexp.MustCompile(`\{([^}]+)}`)
+
+// MswProcessor - Mock Service Worker processor converts the model classes to TypeScript fixture factories
+// (createXxx(overrides) functions with default values per field type) and the service methods to MSW request handlers
+// returning fixtures of the method return type
+type MswProcessor struct {
+	BaseProcessor
+	ModelImport string // Import path of the TypeScript model classes and enums (relative to the output folder)
+}
+
+// NewMswProcessor - Factory method
+func NewMswProcessor(model *model.MetaModel, output string) *MswProcessor {
+	return &MswProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		ModelImport: "../model",
+	}
+}
+
+// WithModelImport sets the import path of the TypeScript model (default is ../model, the model folder of the
+// TypeScript processor when the output is sibling folder)
+func (p *MswProcessor) WithModelImport(importPath string) *MswProcessor {
+	p.ModelImport = importPath
+	return p
+}
+
+// mswFixture is factory function of class
+type mswFixture struct {
+	Name     string   // Factory function name (e.g. createUser)
+	Generics string   // Generic parameters declaration (e.g. <T = any>)
+	Type     string   // Class type (e.g. EntityResponse<T>)
+	Base     string   // Factory of the base class (empty if the class does not extend other class)
+	Fields   []string // Default values of the class fields (json: value)
+	Docs     string   // Class documentation
+}
+
+// mswHandler is request handler of service method
+type mswHandler struct {
+	Method   string // MSW http function (get, post ...)
+	Path     string // URL path pattern (e.g. */users/:id)
+	Response string // Response resolver expression
+	Docs     string // Method documentation
+}
+
+// mswService is the request handlers of service
+type mswService struct {
+	Name     string // Handlers list name (e.g. usersServiceHandlers)
+	Docs     string // Service documentation
+	Handlers []*mswHandler
+}
+
+// mswFiles is the template data of the fixtures and handlers files
+type mswFiles struct {
+	ModelImport string
+	Imports     []string // Imported classes and enums (fixtures)
+	Fixtures    []*mswFixture
+	Factories   []string // Imported factories (handlers)
+	Services    []*mswService
+}
+
+// Start the processor
+func (p *MswProcessor) Start() error {
+	data := &mswFiles{ModelImport: p.ModelImport}
+	for _, class := range p.Model.ListClasses() {
+		if !class.IsParam {
+			data.Fixtures = append(data.Fixtures, p.fixture(class, data))
+		}
+	}
+	slices.Sort(data.Imports)
+
+	for _, service := range p.Model.ListServices() {
+		data.Services = append(data.Services, p.service(service, data))
+	}
+	slices.Sort(data.Factories)
+
+	funcMap := template.FuncMap{
+		"join": func(list []string) string { return strings.Join(list, ", ") },
+	}
+	for _, fileName := range []string{"fixtures.ts", "handlers.ts"} {
+		tmpl, err := template.New(fileName).Funcs(funcMap).Parse(map[string]string{"fixtures.ts": mswFixturesTemplate, "handlers.ts": mswHandlersTemplate}[fileName])
+		if err != nil {
+			return fmt.Errorf("error parsing template [%s]: %s", fileName, err.Error())
+		}
+		var tpl bytes.Buffer
+		if err = tmpl.Execute(&tpl, data); err != nil {
+			return fmt.Errorf("error executing template [%s]: %s", fileName, err.Error())
+		}
+		if err = p.writeFile(path.Join(p.Output, fileName), p.trimNewLines(tpl.String())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Build the factory function of class
+func (p *MswProcessor) fixture(class *model.ClassInfo, data *mswFiles) *mswFixture {
+	fixture := &mswFixture{
+		Name: mswFactoryName(class.Name),
+		Type: class.Name,
+		Docs: docsLine(class.Docs),
+	}
+	mswImport(data, class.Name)
+
+	if len(class.GenericTypes) > 0 {
+		keys := make([]string, 0)
+		defaults := make([]string, 0)
+		for _, kv := range class.GenericTypes {
+			keys = append(keys, kv.Key)
+			defaults = append(defaults, kv.Key+" = any")
+		}
+		fixture.Generics = "<" + strings.Join(defaults, ", ") + ">"
+		fixture.Type = class.Name + "<" + strings.Join(keys, ", ") + ">"
+	}
+	if class.IsExtend && p.Model.GetClass(class.BaseClass) != nil {
+		fixture.Base = mswFactoryName(class.BaseClass)
+	}
+
+	for _, field := range class.Fields {
+		fixture.Fields = append(fixture.Fields, fmt.Sprintf("%s: %s", field.Json, p.fieldValue(class, field, data)))
+	}
+	return fixture
+}
+
+// Build the default value of class field: enums use their first non-zero value, classes use their factory (null when
+// the class refers back to the field class), arrays are empty
+func (p *MswProcessor) fieldValue(class *model.ClassInfo, field *model.FieldInfo, data *mswFiles) string {
+	if len(field.Example) > 0 {
+		return exampleLiteral(field.Example, fieldGoType(field))
+	}
+	if field.IsMap {
+		return "{}"
+	}
+	node := model.NewTypeNodeFromGoType(fieldGoType(field))
+	if node == nil {
+		return "null"
+	}
+	if node.IsArray {
+		return "[]"
+	}
+	if mswGenericParam(class, node.Name) {
+		return fmt.Sprintf("null as unknown as %s", node.Name)
+	}
+	if fieldClass := p.Model.GetClass(node.Name); fieldClass != nil {
+		if fieldClass.IsParam || p.refers(fieldClass, class.Name, make(map[string]bool)) {
+			return "null"
+		}
+		return mswFactoryName(fieldClass.Name) + "()"
+	}
+	if enum := p.Model.GetEnum(node.Name); enum != nil {
+		mswImport(data, enum.Name)
+		return mswEnumValue(enum)
+	}
+	return examplePrimitive(node.Name, field.Json, field.Format)
+}
+
+// Check if the class refers to the target class (by field types or base class, recursively)
+func (p *MswProcessor) refers(class *model.ClassInfo, target string, visited map[string]bool) bool {
+	if class.Name == target {
+		return true
+	}
+	if visited[class.Name] {
+		return false
+	}
+	visited[class.Name] = true
+	for _, field := range p.Model.ListClassFields(class) {
+		if node := model.NewTypeNodeFromGoType(fieldGoType(field)); node != nil && !node.IsArray {
+			if fieldClass := p.Model.GetClass(node.Name); fieldClass != nil && p.refers(fieldClass, target, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Build the request handlers of service
+func (p *MswProcessor) service(service *model.ServiceInfo, data *mswFiles) *mswService {
+	name := service.TsName
+	if len(name) == 0 {
+		name = service.Name
+	}
+	result := &mswService{Name: toCamelCase(name) + "Handlers", Docs: docsLine(service.Docs)}
+	for _, mi := range service.Methods {
+		if mi.IsSocketMessage || len(mi.Method) == 0 {
+			continue
+		}
+		result.Handlers = append(result.Handlers, &mswHandler{
+			Method:   strings.ToLower(mi.Method),
+			Path:     "*" + mswPathParam.ReplaceAllString(service.MethodPath(mi), ":$1"),
+			Response: p.response(mi, data),
+			Docs:     docsLine(mi.Docs),
+		})
+	}
+	return result
+}
+
+// Build the response resolver expression of method (fixture of the return type or the method @Example)
+func (p *MswProcessor) response(mi *model.MethodInfo, data *mswFiles) string {
+	if mi.ReturnClass == "StreamContent" {
+		return "new HttpResponse(new Blob(['mock content']), { headers: { 'Content-Type': 'application/octet-stream' } })"
+	}
+	if mi.ReturnType == nil {
+		return "new HttpResponse(null, { status: 204 })"
+	}
+	if len(mi.Example) > 0 {
+		return fmt.Sprintf("HttpResponse.json(%s)", exampleLiteral(mi.Example, ""))
+	}
+	return fmt.Sprintf("HttpResponse.json(%s)", p.typeValue(mi.ReturnType, data))
+}
+
+// Build fixture expression of type node, the generic type arguments override the generic fields of the class
+// (e.g. EntityResponse<User> -> createEntityResponse({ data: createUser() }))
+func (p *MswProcessor) typeValue(node *model.TypeNode, data *mswFiles) string {
+	if node.IsArray {
+		item := *node
+		item.IsArray = false
+		return "[" + p.typeValue(&item, data) + "]"
+	}
+
+	if class := p.Model.GetClass(node.Name); class != nil && !class.IsParam {
+		factory := mswFactoryName(class.Name)
+		if !slices.Contains(data.Factories, factory) {
+			data.Factories = append(data.Factories, factory)
+		}
+
+		overrides := make([]string, 0)
+		for i, kv := range class.GenericTypes {
+			if i >= len(node.Args) {
+				break
+			}
+			for _, field := range p.Model.ListClassFields(class) {
+				fieldType := fieldGoType(field)
+				if strings.TrimPrefix(fieldType, "[]") != kv.Key {
+					continue
+				}
+				arg := *node.Args[i]
+				arg.IsArray = arg.IsArray || strings.HasPrefix(fieldType, "[]")
+				overrides = append(overrides, fmt.Sprintf("%s: %s", field.Json, p.typeValue(&arg, data)))
+			}
+		}
+		if len(overrides) == 0 {
+			return factory + "()"
+		}
+		return fmt.Sprintf("%s({ %s })", factory, strings.Join(overrides, ", "))
+	}
+	if enum := p.Model.GetEnum(node.Name); enum != nil {
+		return exampleEnumValue(enum)
+	}
+	if value := examplePrimitive(node.Name, "", ""); value != "null" {
+		return value
+	}
+	return "{}"
+}
+
+// Get the factory function name of class
+func mswFactoryName(className string) string {
+	return "create" + className
+}
+
+// Get the default value of enum (the first value which is not zero, zero is usually the undefined value)
+func mswEnumValue(enum *model.EnumInfo) string {
+	for _, ev := range enum.Values {
+		if ev.Value != 0 {
+			return enum.Name + "." + ev.Name
+		}
+	}
+	if len(enum.Values) > 0 {
+		return enum.Name + "." + enum.Values[0].Name
+	}
+	return fmt.Sprintf("0 as %s", enum.Name)
+}
+
+// Check if the type is generic parameter of the class (e.g. T)
+func mswGenericParam(class *model.ClassInfo, typeName string) bool {
+	for _, kv := range class.GenericTypes {
+		if kv.Key == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// Add class or enum to the fixtures imports
+func mswImport(data *mswFiles, name string) {
+	if !slices.Contains(data.Imports, name) {
+		data.Imports = append(data.Imports, name)
+	}
+}
+
+// region MSW templates ------------------------------------------------------------------------------------------------
+
+var mswFixturesTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+// Fixture factories of the model classes, the overrides replace the default values
+import { {{join .Imports}} } from '{{.ModelImport}}';
+{{range .Fixtures}}
+{{with .Docs}}// {{.}}
+{{end}}export function {{.Name}}{{.Generics}}(overrides: Partial<{{.Type}}> = {}): {{.Type}} {
+  return {
+    {{with .Base}}...{{.}}(),
+    {{end}}{{range .Fields}}{{.}},
+    {{end}}...overrides,
+  } as {{.Type}};
+}
+{{end}}
+`
+
+var mswHandlersTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+// Mock Service Worker request handlers of the API services
+import { http, HttpResponse } from 'msw';
+{{with .Factories}}import { {{join .}} } from './fixtures';
+{{end}}
+{{range .Services}}
+{{with .Docs}}// {{.}}
+{{end}}export const {{.Name}} = [
+{{range .Handlers}}  {{with .Docs}}// {{.}}
+  {{end}}http.{{.Method}}('{{.Path}}', () => {{.Response}}),
+{{end}}];
+{{end}}
+// All the request handlers
+export const handlers = [
+{{range .Services}}  ...{{.Name}},
+{{end}}];
+`
+
+// endregion
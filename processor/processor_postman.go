@@ -0,0 +1,371 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanPathParam matches path parameter in method path (e.g. {id})
+var postmanPathParam = regexp.MustCompile(`\{([^}/]+)}`)
+
+// postmanNonAlphanumeric matches the separators of header name (e.g. X-API-KEY)
+var postmanNonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// PostmanProcessor - Postman processor converts the services to Postman v2.1 collection (folder per resource group and
+// service) with environment file, and to JetBrains / VS Code .http request files (file per resource group)
+type PostmanProcessor struct {
+	BaseProcessor
+	Name    string // Collection name (default: API)
+	BaseURL string // Default value of the baseUrl environment variable (e.g. https://api.example.com)
+}
+
+// NewPostmanProcessor - Factory method
+func NewPostmanProcessor(model *model.MetaModel, output string) *PostmanProcessor {
+	return &PostmanProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Name: "API",
+	}
+}
+
+// WithName sets the collection name (used also for the collection and environment file names)
+func (p *PostmanProcessor) WithName(name string) *PostmanProcessor {
+	p.Name = name
+	return p
+}
+
+// WithBaseURL sets the default value of the baseUrl environment variable
+func (p *PostmanProcessor) WithBaseURL(baseURL string) *PostmanProcessor {
+	p.BaseURL = baseURL
+	return p
+}
+
+// postmanCollection is the Postman collection document (v2.1)
+type postmanCollection struct {
+	Info     postmanInfo        `json:"info"`
+	Item     []*postmanItem     `json:"item"`
+	Variable []*postmanVariable `json:"variable,omitempty"`
+}
+
+// postmanInfo is the collection information
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is a folder (list of items) or a request
+type postmanItem struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Item        []*postmanItem     `json:"item,omitempty"`
+	Request     *postmanRequest    `json:"request,omitempty"`
+	Response    []*postmanResponse `json:"response,omitempty"`
+}
+
+// postmanResponse is a saved example response of request
+type postmanResponse struct {
+	Name     string             `json:"name"`
+	Status   string             `json:"status"`
+	Code     int                `json:"code"`
+	Language string             `json:"_postman_previewlanguage"`
+	Header   []*postmanVariable `json:"header"`
+	Body     string             `json:"body"`
+}
+
+// postmanRequest is a single HTTP request
+type postmanRequest struct {
+	Method      string             `json:"method"`
+	Header      []*postmanVariable `json:"header"`
+	Url         postmanUrl         `json:"url"`
+	Body        *postmanBody       `json:"body,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// postmanUrl is the request URL with path and query parameters
+type postmanUrl struct {
+	Raw      string             `json:"raw"`
+	Host     []string           `json:"host"`
+	Path     []string           `json:"path,omitempty"`
+	Query    []*postmanVariable `json:"query,omitempty"`
+	Variable []*postmanVariable `json:"variable,omitempty"`
+}
+
+// postmanBody is the request body (raw JSON or form data for file upload)
+type postmanBody struct {
+	Mode     string             `json:"mode"`
+	Raw      string             `json:"raw,omitempty"`
+	FormData []*postmanFormData `json:"formdata,omitempty"`
+	Options  map[string]any     `json:"options,omitempty"`
+}
+
+// postmanFormData is a form data field
+type postmanFormData struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Src         string `json:"src,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// postmanVariable is a key-value pair (header, query parameter, path variable or collection variable)
+type postmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// postmanEnvironment is the Postman environment document
+type postmanEnvironment struct {
+	Name   string                     `json:"name"`
+	Values []*postmanEnvironmentValue `json:"values"`
+	Scope  string                     `json:"_postman_variable_scope"`
+}
+
+// postmanEnvironmentValue is a single environment variable
+type postmanEnvironmentValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// httpFile is the template data of .http request file (all the requests of resource group)
+type httpFile struct {
+	Group     string
+	Variables []*postmanVariable // Path parameters with example values
+	Requests  []*httpRequest
+}
+
+// httpRequest is the template data of single request in .http file
+type httpRequest struct {
+	Name    string
+	Docs    string
+	Method  string
+	Url     string
+	Headers []string
+	Body    string
+}
+
+// Start the processor
+func (p *PostmanProcessor) Start() error {
+	groups := listServicesGroups(p.Model.ListServices())
+	variables := p.listVariables()
+
+	collection := &postmanCollection{
+		Info:     postmanInfo{Name: p.Name, Schema: postmanSchema},
+		Item:     make([]*postmanItem, 0),
+		Variable: variables,
+	}
+	for _, group := range groups {
+		folder := &postmanItem{Name: group.Name, Item: make([]*postmanItem, 0)}
+		for _, service := range group.Services {
+			serviceFolder := &postmanItem{Name: service.TsName, Description: joinDocs(deprecatedDocs(service.Docs, service.Deprecated)), Item: make([]*postmanItem, 0)}
+			for _, mi := range service.Methods {
+				serviceFolder.Item = append(serviceFolder.Item, p.postmanRequest(service, mi))
+			}
+			folder.Item = append(folder.Item, serviceFolder)
+		}
+		collection.Item = append(collection.Item, folder)
+	}
+
+	fileName := removeSpaces(p.Name)
+	if err := p.writeJson(path.Join(p.Output, fileName+".postman_collection.json"), collection); err != nil {
+		return err
+	}
+
+	environment := &postmanEnvironment{Name: p.Name, Values: make([]*postmanEnvironmentValue, 0), Scope: "environment"}
+	for _, v := range variables {
+		environment.Values = append(environment.Values, &postmanEnvironmentValue{Key: v.Key, Value: v.Value, Type: "default", Enabled: true})
+	}
+	if err := p.writeJson(path.Join(p.Output, fileName+".postman_environment.json"), environment); err != nil {
+		return err
+	}
+
+	// JetBrains HTTP client environment (the same structure is used by the VS Code REST client settings)
+	env := make(map[string]string)
+	for _, v := range variables {
+		env[v.Key] = v.Value
+	}
+	if err := p.writeJson(path.Join(p.Output, "http", "http-client.env.json"), map[string]any{"default": env}); err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if err := p.writeHttpFile(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List the environment variables: base URL and a variable per HTTP header of all the services
+func (p *PostmanProcessor) listVariables() []*postmanVariable {
+	list := []*postmanVariable{{Key: "baseUrl", Value: p.BaseURL}}
+	for _, service := range p.Model.ListServices() {
+		for _, mi := range service.Methods {
+			for _, header := range htmlMethodHeaders(service, mi) {
+				key := postmanHeaderVariable(header)
+				if !slices.ContainsFunc(list, func(v *postmanVariable) bool { return v.Key == key }) {
+					list = append(list, &postmanVariable{Key: key})
+				}
+			}
+		}
+	}
+	return list
+}
+
+// Build the Postman request of service method
+func (p *PostmanProcessor) postmanRequest(service *model.ServiceInfo, mi *model.MethodInfo) *postmanItem {
+	route := service.MethodPath(mi)
+	request := &postmanRequest{
+		Method:      mi.Method,
+		Header:      make([]*postmanVariable, 0),
+		Description: joinDocs(deprecatedDocs(mi.Docs, methodDeprecation(service, mi))),
+	}
+
+	for _, header := range htmlMethodHeaders(service, mi) {
+		request.Header = append(request.Header, &postmanVariable{Key: header, Value: fmt.Sprintf("{{%s}}", postmanHeaderVariable(header))})
+	}
+
+	route = postmanPathParam.ReplaceAllString(route, ":$1")
+	request.Url = postmanUrl{Raw: "{{baseUrl}}" + route, Host: []string{"{{baseUrl}}"}}
+	for _, part := range strings.Split(route, "/") {
+		if len(part) > 0 {
+			request.Url.Path = append(request.Url.Path, part)
+		}
+	}
+	for _, param := range mi.PathParams {
+		request.Url.Variable = append(request.Url.Variable, &postmanVariable{Key: param.Json, Value: sampleParam(p.Model, param), Description: joinDocs(param.Docs)})
+	}
+	query := make([]string, 0)
+	for _, param := range mi.QueryParams {
+		value := sampleParam(p.Model, param)
+		request.Url.Query = append(request.Url.Query, &postmanVariable{Key: param.Json, Value: value, Description: joinDocs(param.Docs)})
+		query = append(query, param.Json+"="+url.QueryEscape(value))
+	}
+	if len(query) > 0 {
+		request.Url.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if mi.FileParam != nil {
+		request.Body = &postmanBody{Mode: "formdata", FormData: []*postmanFormData{{Key: "fileKey", Type: "file", Description: joinDocs(mi.FileParam.Docs)}}}
+	} else if HasRequestBody(mi) {
+		request.Header = append(request.Header, &postmanVariable{Key: "Content-Type", Value: "application/json"})
+		request.Body = &postmanBody{Mode: "raw", Raw: sampleBody(p.Model, mi.BodyParam), Options: map[string]any{"raw": map[string]string{"language": "json"}}}
+	}
+	item := &postmanItem{Name: mi.Name, Request: request}
+	if body := sampleResponse(p.Model, mi); len(body) > 0 {
+		item.Response = []*postmanResponse{{
+			Name:     "Example",
+			Status:   "OK",
+			Code:     200,
+			Language: "json",
+			Header:   []*postmanVariable{{Key: "Content-Type", Value: "application/json"}},
+			Body:     body,
+		}}
+	}
+	return item
+}
+
+// Write the .http request file of the resource group
+func (p *PostmanProcessor) writeHttpFile(group *htmlGroup) error {
+	file := &httpFile{Group: group.Name, Variables: make([]*postmanVariable, 0), Requests: make([]*httpRequest, 0)}
+	for _, service := range group.Services {
+		for _, mi := range service.Methods {
+			request := &httpRequest{
+				Name:    service.TsName + "." + mi.TsName,
+				Docs:    docsLine(deprecatedDocs(mi.Docs, methodDeprecation(service, mi))),
+				Method:  mi.Method,
+				Url:     "{{baseUrl}}" + postmanPathParam.ReplaceAllString(service.MethodPath(mi), "{{$1}}"),
+				Headers: make([]string, 0),
+			}
+			for _, param := range mi.PathParams {
+				if !slices.ContainsFunc(file.Variables, func(v *postmanVariable) bool { return v.Key == param.Json }) {
+					file.Variables = append(file.Variables, &postmanVariable{Key: param.Json, Value: sampleParam(p.Model, param)})
+				}
+			}
+			query := make([]string, 0)
+			for _, param := range mi.QueryParams {
+				query = append(query, param.Json+"="+url.QueryEscape(sampleParam(p.Model, param)))
+			}
+			if len(query) > 0 {
+				request.Url += "?" + strings.Join(query, "&")
+			}
+			for _, header := range htmlMethodHeaders(service, mi) {
+				request.Headers = append(request.Headers, fmt.Sprintf("%s: {{%s}}", header, postmanHeaderVariable(header)))
+			}
+			if mi.FileParam != nil {
+				request.Headers = append(request.Headers, "Content-Type: multipart/form-data; boundary=boundary")
+				request.Body = "--boundary\nContent-Disposition: form-data; name=\"fileKey\"; filename=\"file\"\n\n< ./file\n--boundary--"
+			} else if HasRequestBody(mi) {
+				request.Headers = append(request.Headers, "Content-Type: application/json")
+				request.Body = sampleBody(p.Model, mi.BodyParam)
+			}
+			file.Requests = append(file.Requests, request)
+		}
+	}
+
+	tmpl, err := template.New("http").Parse(httpFileTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template [http]: %s", err.Error())
+	}
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, file); err != nil {
+		return fmt.Errorf("error executing template [http]: %s", err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, "http", removeSpaces(group.Name)+".http"), p.trimNewLines(tpl.String()))
+}
+
+// Write any object as indented JSON (without escaping of HTML characters)
+func (p *PostmanProcessor) writeJson(fileName string, doc any) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("error marshaling %s: %s", fileName, err.Error())
+	}
+	return p.writeFile(fileName, buffer.String())
+}
+
+// Get the variable name of HTTP header (e.g. X-API-KEY -> xApiKey)
+func postmanHeaderVariable(header string) string {
+	name := ""
+	for i, part := range postmanNonAlphanumeric.Split(header, -1) {
+		if i == 0 {
+			name += strings.ToLower(part)
+		} else {
+			name += model.Title(strings.ToLower(part))
+		}
+	}
+	return name
+}
+
+// region Http file template -------------------------------------------------------------------------------------------
+
+var httpFileTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+# {{.Group}} requests, the baseUrl and headers variables are defined in http-client.env.json
+{{range .Variables}}
+@{{.Key}} = {{.Value}}{{end}}
+{{range .Requests}}
+### {{.Name}}{{with .Docs}} - {{.}}{{end}}
+{{.Method}} {{.Url}}
+{{range .Headers}}{{.}}
+{{end}}{{with .Body}}
+{{.}}
+{{end}}{{end}}`
+
+// endregion
@@ -0,0 +1,565 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var protobufTypes = map[string]string{
+	"double":        "double",
+	"float":         "double",
+	"float32":       "float",
+	"float64":       "double",
+	"int":           "int64",
+	"int32":         "int32",
+	"int64":         "int64",
+	"uint":          "uint64",
+	"uint32":        "uint32",
+	"uint64":        "uint64",
+	"sint":          "sint64",
+	"sint32":        "sint32",
+	"sint64":        "sint64",
+	"fixed32":       "fixed32",
+	"fixed64":       "fixed64",
+	"sfixed32":      "sfixed32",
+	"sfixed64":      "sfixed64",
+	"bool":          "bool",
+	"string":        "string",
+	"bytes":         "bytes",
+	"any":           "google.protobuf.Value",
+	"Timestamp":     "int64",
+	"Json":          "google.protobuf.Struct",
+	"StreamContent": "bytes",
+	"number":        "double",
+	"boolean":       "bool",
+}
+
+// Well known types and the files declaring them
+var protobufImports = map[string]string{
+	"google.protobuf.Value":     "google/protobuf/struct.proto",
+	"google.protobuf.Struct":    "google/protobuf/struct.proto",
+	"google.protobuf.ListValue": "google/protobuf/struct.proto",
+	"google.protobuf.Empty":     "google/protobuf/empty.proto",
+}
+
+// ProtobufLockFile is the name of the file (in the output folder) which keeps the assigned field numbers
+const ProtobufLockFile = "proto.lock.json"
+
+// ProtobufProcessor - Protobuf processor converts the meta model to proto3 schema (messages, enums and gRPC services)
+type ProtobufProcessor struct {
+	BaseProcessor
+	Package string // Protobuf package name (e.g. acme.api.v1)
+}
+
+// NewProtobufProcessor - Factory method
+func NewProtobufProcessor(model *model.MetaModel, output string, pkg string) *ProtobufProcessor {
+	return &ProtobufProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Package: pkg,
+	}
+}
+
+// protoLock keeps the field numbers assigned to every message, a number is never reused for another field
+type protoLock struct {
+	Messages map[string]*protoLockMessage `json:"messages"`
+}
+
+// protoLockMessage keeps the field numbers of a single message, fields removed from the model are reserved
+type protoLockMessage struct {
+	Fields   map[string]int `json:"fields"`
+	Reserved map[string]int `json:"reserved,omitempty"`
+}
+
+type protoFile struct {
+	Package  string
+	Imports  []string
+	Enums    []*protoEnum
+	Messages []*protoMessage
+	Services []*protoService
+}
+
+type protoEnum struct {
+	Name       string
+	Docs       []string
+	AllowAlias bool
+	Deprecated bool
+	Values     []*protoEnumValue
+}
+
+type protoEnumValue struct {
+	Name       string
+	Number     int
+	Docs       []string
+	Deprecated bool
+}
+
+type protoMessage struct {
+	Name          string
+	Docs          []string
+	Deprecated    bool
+	Fields        []*protoField
+	Reserved      []int
+	ReservedNames []string
+}
+
+type protoField struct {
+	Name     string
+	Type     string
+	Number   int
+	Repeated bool
+	Options  string
+	Docs     []string
+}
+
+type protoService struct {
+	Name       string
+	Docs       []string
+	Deprecated bool
+	Rpcs       []*protoRpc
+}
+
+type protoRpc struct {
+	Name       string
+	Request    string
+	Response   string
+	Docs       []string
+	Deprecated bool
+}
+
+// protoBuilder collects the messages of the proto file, generic classes are generated per type arguments
+type protoBuilder struct {
+	mm       *model.MetaModel
+	lock     *protoLock
+	imports  map[string]bool
+	added    map[string]bool
+	messages []*protoMessage
+}
+
+// Start the processor
+func (p *ProtobufProcessor) Start() error {
+
+	lockFile := path.Join(p.Output, ProtobufLockFile)
+	lock, err := p.readLock(lockFile)
+	if err != nil {
+		return err
+	}
+
+	b := &protoBuilder{
+		mm:       p.Model,
+		lock:     lock,
+		imports:  make(map[string]bool),
+		added:    make(map[string]bool),
+		messages: make([]*protoMessage, 0),
+	}
+
+	file := &protoFile{Package: p.Package}
+	for _, enum := range p.Model.ListEnums() {
+		file.Enums = append(file.Enums, b.buildEnum(enum))
+	}
+
+	// Generic classes are generated only for their actual type arguments
+	for _, class := range p.Model.ListClasses() {
+		if !class.IsParam && !class.IsGeneric {
+			b.addClass(class, nil)
+		}
+	}
+
+	for _, service := range p.Model.ListServices() {
+		file.Services = append(file.Services, b.buildService(service))
+	}
+
+	file.Messages = b.messages
+	for imp := range b.imports {
+		file.Imports = append(file.Imports, imp)
+	}
+	sort.Strings(file.Imports)
+
+	funcMap := template.FuncMap{
+		"protoDocs":   protoDocs,
+		"protoQuote":  strconv.Quote,
+		"joinNumbers": joinNumbers,
+		"joinNames":   joinQuotedNames,
+	}
+
+	tmpl, err := template.New("proto").Funcs(funcMap).Parse(protobufTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template [proto]: %s", err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, file); err != nil {
+		return fmt.Errorf("error executing template [proto]: %s", err.Error())
+	}
+
+	fileName := path.Join(p.Output, fmt.Sprintf("%s.proto", p.Package))
+	if err = p.writeFile(fileName, p.trimNewLines(tpl.String())); err != nil {
+		return err
+	}
+	return p.writeLock(lockFile, lock)
+}
+
+// Read the lock file, missing lock file means no field number was assigned yet
+func (p *ProtobufProcessor) readLock(fileName string) (*protoLock, error) {
+	lock := &protoLock{Messages: make(map[string]*protoLockMessage)}
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return lock, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("error reading lock file [%s]: %s", fileName, err.Error())
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]*protoLockMessage)
+	}
+	return lock, nil
+}
+
+// Write the lock file
+func (p *ProtobufProcessor) writeLock(fileName string, lock *protoLock) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return p.writeFile(fileName, string(data)+"\n")
+}
+
+// Build proto enum, the first value of proto3 enum must be zero
+func (b *protoBuilder) buildEnum(enum *model.EnumInfo) *protoEnum {
+	prefix := protoConstName(enum.Name) + "_"
+	result := &protoEnum{Name: enum.Name, Docs: deprecatedDocs(enum.Docs, enum.Deprecated), Deprecated: enum.Deprecated != nil}
+
+	hasZero := false
+	numbers := make(map[int]bool)
+	for _, ev := range enum.Values {
+		if numbers[ev.Value] {
+			result.AllowAlias = true
+		}
+		numbers[ev.Value] = true
+		hasZero = hasZero || ev.Value == 0
+
+		name := protoConstName(ev.Name)
+		if !strings.HasPrefix(name, prefix) {
+			name = prefix + name
+		}
+		result.Values = append(result.Values, &protoEnumValue{
+			Name:       name,
+			Number:     ev.Value,
+			Docs:       deprecatedDocs(ev.Docs, ev.Deprecated),
+			Deprecated: ev.Deprecated != nil,
+		})
+	}
+	if !hasZero {
+		zero := &protoEnumValue{Name: prefix + "UNSPECIFIED", Number: 0}
+		result.Values = append([]*protoEnumValue{zero}, result.Values...)
+	}
+
+	// Move the zero value to the top
+	sort.SliceStable(result.Values, func(i, j int) bool {
+		return result.Values[i].Number == 0 && result.Values[j].Number != 0
+	})
+	return result
+}
+
+// Add message of class (with type arguments for generic class) and return the message name
+func (b *protoBuilder) addClass(class *model.ClassInfo, args []*model.TypeNode) string {
+	name, bindings := bindGenericTypes(class, args)
+	if b.added[name] {
+		return name
+	}
+	b.added[name] = true
+
+	msg := &protoMessage{Name: name, Docs: deprecatedDocs(class.Docs, class.Deprecated), Deprecated: class.Deprecated != nil}
+	b.messages = append(b.messages, msg)
+
+	fields := make([]*protoField, 0)
+	for _, field := range b.mm.ListClassFields(class) {
+		node := substituteTypeNode(model.NewTypeNodeFromGoType(field.Type), bindings)
+		pt, repeated := b.resolveType(node)
+		if field.IsArray {
+			if repeated {
+				pt = b.useType("google.protobuf.ListValue")
+			}
+			repeated = true
+		}
+		options := protoJsonOption(field.Json)
+		if field.Deprecated != nil {
+			options = strings.TrimPrefix(options+", deprecated = true", ", ")
+		}
+		fields = append(fields, &protoField{
+			Name:     protoFieldName(field.Json),
+			Type:     pt,
+			Repeated: repeated,
+			Options:  options,
+			Docs:     deprecatedDocs(field.Docs, field.Deprecated),
+		})
+	}
+	b.numberFields(msg, fields)
+	return name
+}
+
+// Resolve the proto type of type node (after generic type substitution)
+func (b *protoBuilder) resolveType(node *model.TypeNode) (string, bool) {
+	if node == nil {
+		return b.useType("google.protobuf.Value"), false
+	}
+	if pt, ok := protobufTypes[node.Name]; ok {
+		return b.useType(pt), node.IsArray
+	}
+	if b.mm.GetEnum(node.Name) != nil {
+		return node.Name, node.IsArray
+	}
+	if class := b.mm.GetClass(node.Name); class != nil {
+		return b.addClass(class, node.Args), node.IsArray
+	}
+	return b.useType("google.protobuf.Value"), node.IsArray
+}
+
+// Register import of well known type
+func (b *protoBuilder) useType(pt string) string {
+	if imp, ok := protobufImports[pt]; ok {
+		b.imports[imp] = true
+	}
+	return pt
+}
+
+// Build gRPC service, every rpc gets its own request message with all the method parameters
+func (b *protoBuilder) buildService(service *model.ServiceInfo) *protoService {
+	result := &protoService{Name: service.TsName, Docs: deprecatedDocs(service.Docs, service.Deprecated), Deprecated: service.Deprecated != nil}
+	for _, mi := range service.Methods {
+		rpc := &protoRpc{
+			Name:       strings.ToUpper(mi.Name[0:1]) + mi.Name[1:],
+			Docs:       append(deprecatedDocs(mi.Docs, mi.Deprecated), "HTTP: "+service.MethodRoute(mi)),
+			Deprecated: methodDeprecation(service, mi) != nil,
+		}
+
+		// Build request message
+		params := listMethodParams(*mi)
+		if len(params) > 0 {
+			msg := &protoMessage{Name: fmt.Sprintf("%s%sRequest", result.Name, rpc.Name)}
+			fields := make([]*protoField, 0)
+			for _, param := range params {
+				node := model.NewTypeNode(param.Type)
+				pt, repeated := "bytes", false
+				if param.ParamType != "file" {
+					pt, repeated = b.resolveType(node)
+				}
+				if param.IsArray {
+					if repeated {
+						pt = b.useType("google.protobuf.ListValue")
+					}
+					repeated = true
+				}
+				fields = append(fields, &protoField{
+					Name:     protoFieldName(param.Json),
+					Type:     pt,
+					Repeated: repeated,
+					Options:  protoJsonOption(param.Json),
+					Docs:     param.Docs,
+				})
+			}
+			b.messages = append(b.messages, msg)
+			b.numberFields(msg, fields)
+			rpc.Request = msg.Name
+		}
+
+		// Build response, scalars and lists are wrapped with response message
+		if mi.ReturnType != nil {
+			pt, repeated := b.resolveType(mi.ReturnType)
+			isMessage := strings.HasPrefix(pt, "google.protobuf.") || b.mm.GetClass(mi.ReturnType.Name) != nil
+			if repeated || !isMessage {
+				msg := &protoMessage{Name: fmt.Sprintf("%s%sResponse", result.Name, rpc.Name)}
+				b.messages = append(b.messages, msg)
+				b.numberFields(msg, []*protoField{{Name: "value", Type: pt, Repeated: repeated}})
+				pt = msg.Name
+			}
+			rpc.Response = pt
+		}
+
+		// Methods without parameters or return type use the Empty message
+		if len(rpc.Request) == 0 {
+			rpc.Request = b.useType("google.protobuf.Empty")
+		}
+		if len(rpc.Response) == 0 {
+			rpc.Response = b.useType("google.protobuf.Empty")
+		}
+		result.Rpcs = append(result.Rpcs, rpc)
+	}
+	return result
+}
+
+// Assign field numbers from the lock file, new fields get the next free number and removed fields are reserved
+func (b *protoBuilder) numberFields(msg *protoMessage, fields []*protoField) {
+	lm, ok := b.lock.Messages[msg.Name]
+	if !ok {
+		lm = &protoLockMessage{Fields: make(map[string]int)}
+		b.lock.Messages[msg.Name] = lm
+	}
+	if lm.Fields == nil {
+		lm.Fields = make(map[string]int)
+	}
+
+	next := 1
+	for _, numbers := range []map[string]int{lm.Fields, lm.Reserved} {
+		for _, n := range numbers {
+			if n >= next {
+				next = n + 1
+			}
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, field := range fields {
+		current[field.Name] = true
+		if n, exists := lm.Fields[field.Name]; exists {
+			field.Number = n
+		} else if n, exists = lm.Reserved[field.Name]; exists {
+			// Field which was removed and added back gets its original number
+			field.Number = n
+			lm.Fields[field.Name] = n
+			delete(lm.Reserved, field.Name)
+		} else {
+			// Numbers 19000-19999 are reserved by protobuf implementation
+			if next >= 19000 && next <= 19999 {
+				next = 20000
+			}
+			field.Number = next
+			lm.Fields[field.Name] = next
+			next++
+		}
+	}
+
+	for name, n := range lm.Fields {
+		if !current[name] {
+			if lm.Reserved == nil {
+				lm.Reserved = make(map[string]int)
+			}
+			lm.Reserved[name] = n
+			delete(lm.Fields, name)
+		}
+	}
+	if len(lm.Reserved) == 0 {
+		lm.Reserved = nil
+	}
+
+	msg.Fields = fields
+	for name, n := range lm.Reserved {
+		msg.Reserved = append(msg.Reserved, n)
+		msg.ReservedNames = append(msg.ReservedNames, name)
+	}
+	sort.Ints(msg.Reserved)
+	sort.Strings(msg.ReservedNames)
+}
+
+// convert name to proto field name (snake_case)
+func protoFieldName(name string) string {
+	result := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, toSnakeCase(name))
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
+
+// convert name to proto enum value name (UPPER_SNAKE_CASE)
+func protoConstName(name string) string {
+	return strings.ToUpper(protoFieldName(name))
+}
+
+// Get json_name option when the default proto3 JSON name is different from the model JSON name
+func protoJsonOption(jsonName string) string {
+	parts := strings.Split(protoFieldName(jsonName), "_")
+	camel := parts[0]
+	for _, part := range parts[1:] {
+		camel += model.Title(part)
+	}
+	if camel == jsonName {
+		return ""
+	}
+	return fmt.Sprintf("json_name = %s", strconv.Quote(jsonName))
+}
+
+// Build comment from documentation lines
+func protoDocs(docs []string, indent string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
+	}
+	output := ""
+	for _, line := range strings.Split(text, "\n") {
+		output += fmt.Sprintf("%s// %s\n", indent, line)
+	}
+	return output
+}
+
+func joinNumbers(numbers []int) string {
+	list := make([]string, 0)
+	for _, n := range numbers {
+		list = append(list, strconv.Itoa(n))
+	}
+	return strings.Join(list, ", ")
+}
+
+func joinQuotedNames(names []string) string {
+	list := make([]string, 0)
+	for _, name := range names {
+		list = append(list, strconv.Quote(name))
+	}
+	return strings.Join(list, ", ")
+}
+
+// region Protobuf template --------------------------------------------------------------------------------------------
+
+var protobufTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+// Field numbers are kept in ` + ProtobufLockFile + `, commit it together with this file.
+syntax = "proto3";
+
+package {{.Package}};
+{{if .Imports}}
+{{range .Imports}}import {{protoQuote .}};
+{{end}}{{end}}
+{{range .Enums}}
+{{protoDocs .Docs ""}}enum {{.Name}} {
+{{if .AllowAlias}}  option allow_alias = true;
+{{end}}{{if .Deprecated}}  option deprecated = true;
+{{end}}{{range .Values}}{{protoDocs .Docs "  "}}  {{.Name}} = {{.Number}}{{if .Deprecated}} [deprecated = true]{{end}};
+{{end}}}
+{{end}}
+{{range .Messages}}
+{{protoDocs .Docs ""}}message {{.Name}} {
+{{if .Deprecated}}  option deprecated = true;
+{{end}}{{with .Reserved}}  reserved {{joinNumbers .}};
+{{end}}{{with .ReservedNames}}  reserved {{joinNames .}};
+{{end}}{{range .Fields}}{{protoDocs .Docs "  "}}  {{if .Repeated}}repeated {{end}}{{.Type}} {{.Name}} = {{.Number}}{{with .Options}} [{{.}}]{{end}};
+{{end}}}
+{{end}}
+{{range .Services}}
+{{protoDocs .Docs ""}}service {{.Name}} {
+{{if .Deprecated}}  option deprecated = true;
+{{end}}{{range .Rpcs}}{{protoDocs .Docs "  "}}  rpc {{.Name}}({{.Request}}) returns ({{.Response}}){{if .Deprecated}} {
+    option deprecated = true;
+  }{{else}};{{end}}
+{{end}}}
+{{end}}`
+
+// endregion
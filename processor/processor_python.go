@@ -0,0 +1,445 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var pythonTypes = map[string]string{
+	"double":        "float",
+	"float":         "float",
+	"float32":       "float",
+	"float64":       "float",
+	"int":           "int",
+	"int32":         "int",
+	"int64":         "int",
+	"uint":          "int",
+	"uint32":        "int",
+	"uint64":        "int",
+	"sint":          "int",
+	"sint32":        "int",
+	"sint64":        "int",
+	"fixed32":       "int",
+	"fixed64":       "int",
+	"sfixed32":      "int",
+	"sfixed64":      "int",
+	"bool":          "bool",
+	"string":        "str",
+	"bytes":         "bytes",
+	"any":           "Any",
+	"Timestamp":     "int",
+	"Json":          "Dict[str, Any]",
+	"StreamContent": "bytes",
+	"number":        "float",
+	"boolean":       "bool",
+}
+
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+	"self": true,
+}
+
+// PythonProcessor - Python processor converts the meta model to Python package (pydantic models and httpx clients)
+type PythonProcessor struct {
+	BaseProcessor
+}
+
+// NewPythonProcessor - Factory method
+func NewPythonProcessor(model *model.MetaModel, output string) *PythonProcessor {
+	return &PythonProcessor{BaseProcessor{
+		Output: output,
+		Model:  model,
+	}}
+}
+
+// pythonModels is the template data of the models module
+type pythonModels struct {
+	TypeVars []string
+	Enums    []*model.EnumInfo
+	Classes  []*model.ClassInfo
+}
+
+// Start the processor
+func (p *PythonProcessor) Start() error {
+
+	funcMap := template.FuncMap{
+		"pyDocString":       pyDocString,
+		"pyMethodDoc":       pyMethodDoc,
+		"pyComment":         pyComment,
+		"pyName":            pyName,
+		"pyQuote":           strconv.Quote,
+		"pyClassBases":      p.pyClassBases,
+		"pyFieldType":       p.pyFieldType,
+		"pyMethodName":      pyMethodName,
+		"pyMethodParams":    p.pyMethodParams,
+		"pyReturnType":      p.pyReturnType,
+		"pyMethodBody":      p.pyMethodBody,
+		"joinDocs":          joinDocs,
+		"deprecatedDocs":    deprecatedDocs,
+		"methodDeprecation": methodDeprecation,
+		"join":              strings.Join,
+	}
+
+	// Generate models module
+	data := pythonModels{
+		TypeVars: p.listTypeVars(),
+		Enums:    p.Model.ListEnums(),
+		Classes:  p.listClasses(),
+	}
+	if err := p.generate("models.py", pythonModelsTemplate, funcMap, data); err != nil {
+		return err
+	}
+
+	// Generate services module
+	if err := p.generate("services.py", pythonServicesTemplate, funcMap, p.Model.ListServices()); err != nil {
+		return err
+	}
+
+	// Generate package init
+	return p.generate("__init__.py", pythonInitTemplate, funcMap, nil)
+}
+
+// Execute template and write the result to file
+func (p *PythonProcessor) generate(fileName, source string, funcMap template.FuncMap, data any) error {
+	tmpl, err := template.New(fileName).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", fileName, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", fileName, err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, fileName), p.trimNewLines(tpl.String()))
+}
+
+// List classes to generate, base classes first
+func (p *PythonProcessor) listClasses() []*model.ClassInfo {
+	list := make([]*model.ClassInfo, 0)
+	for _, class := range p.Model.ListClasses() {
+		if !class.IsParam {
+			list = append(list, class)
+		}
+	}
+	return sortClassesByInheritance(p.Model, list)
+}
+
+// List all generic type parameters names
+func (p *PythonProcessor) listTypeVars() []string {
+	list := make([]string, 0)
+	exists := make(map[string]bool)
+	for _, class := range p.Model.ListClasses() {
+		for _, gt := range class.GenericTypes {
+			if !exists[gt.Key] {
+				exists[gt.Key] = true
+				list = append(list, gt.Key)
+			}
+		}
+	}
+	return list
+}
+
+// Build the list of the class base classes
+func (p *PythonProcessor) pyClassBases(class *model.ClassInfo) string {
+	bases := make([]string, 0)
+	if len(class.BaseClass) > 0 && p.Model.GetClass(class.BaseClass) != nil {
+		bases = append(bases, class.BaseClass)
+	} else {
+		bases = append(bases, "BaseModel")
+	}
+	if class.IsGeneric && len(class.GenericTypes) > 0 {
+		keys := make([]string, 0)
+		for _, gt := range class.GenericTypes {
+			keys = append(keys, gt.Key)
+		}
+		bases = append(bases, fmt.Sprintf("Generic[%s]", strings.Join(keys, ", ")))
+	}
+	return strings.Join(bases, ", ")
+}
+
+// Get the Python type of class field
+func (p *PythonProcessor) pyFieldType(class *model.ClassInfo, field *model.FieldInfo) string {
+	pyType := p.pyType(model.NewTypeNodeFromGoType(field.Type), class.GenericTypes)
+	if field.IsArray {
+		pyType = fmt.Sprintf("List[%s]", pyType)
+	}
+	return pyType
+}
+
+// Get the Python type of type node
+func (p *PythonProcessor) pyType(node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node == nil {
+		return "Any"
+	}
+
+	result := "Any"
+	if pyType, ok := pythonTypes[node.Name]; ok {
+		result = pyType
+	} else if p.Model.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := p.Model.GetClass(node.Name); class != nil {
+		result = node.Name
+		if class.IsGeneric && len(node.Args) > 0 {
+			args := make([]string, 0)
+			for _, arg := range node.Args {
+				args = append(args, p.pyType(arg, generics))
+			}
+			result = fmt.Sprintf("%s[%s]", node.Name, strings.Join(args, ", "))
+		}
+	} else {
+		for _, gt := range generics {
+			if gt.Key == node.Name {
+				result = node.Name
+			}
+		}
+	}
+
+	if node.IsArray {
+		result = fmt.Sprintf("List[%s]", result)
+	}
+	return result
+}
+
+// Get the Python type of method parameter
+func (p *PythonProcessor) pyParamType(param *model.ParamInfo) string {
+	if param.ParamType == "file" {
+		return "Any"
+	}
+	pyType := p.pyType(model.NewTypeNode(param.Type), nil)
+	if param.IsArray {
+		pyType = fmt.Sprintf("List[%s]", pyType)
+	}
+	return pyType
+}
+
+// Build method input parameters list, path, body and file parameters are required (first), query parameters (and
+// parameters which are not sent) are optional
+func (p *PythonProcessor) pyMethodParams(mi *model.MethodInfo) string {
+	required := []string{"self"}
+	optional := make([]string, 0)
+	for _, param := range listMethodParams(*mi) {
+		if pyRequiredParam(mi, param) {
+			required = append(required, fmt.Sprintf("%s: %s", pyName(param.Json), p.pyParamType(param)))
+		} else {
+			optional = append(optional, fmt.Sprintf("%s: Optional[%s] = None", pyName(param.Json), p.pyParamType(param)))
+		}
+	}
+	return strings.Join(append(required, optional...), ", ")
+}
+
+// Check if the parameter is required (path parameters, and body and file parameters which are sent with the request)
+func pyRequiredParam(mi *model.MethodInfo, param *model.ParamInfo) bool {
+	switch param.ParamType {
+	case "path":
+		return true
+	case "body":
+		return HasRequestBody(mi)
+	case "file":
+		return !mi.IsFileUpload
+	}
+	return false
+}
+
+// Get the method return type
+func (p *PythonProcessor) pyReturnType(mi *model.MethodInfo) string {
+	if mi.IsFileUpload {
+		return "str"
+	}
+	if mi.ReturnType == nil {
+		return "None"
+	}
+	return p.pyType(mi.ReturnType, nil)
+}
+
+// Build method content - invoke the http client
+func (p *PythonProcessor) pyMethodBody(mi *model.MethodInfo) string {
+	url := mi.Path
+	if url == "/" {
+		url = ""
+	}
+	for _, param := range mi.PathParams {
+		url = strings.ReplaceAll(url, fmt.Sprintf("{%s}", param.Json), fmt.Sprintf(`{quote(str(%s), safe='')}`, pyName(param.Json)))
+	}
+	url = fmt.Sprintf(`f"{self.base_url}%s"`, url)
+
+	// Upload handler returns the upload URL
+	if mi.IsFileUpload {
+		return fmt.Sprintf("return %s", url)
+	}
+
+	lines := []string{fmt.Sprintf("response = self.client.request(%q, %s,", strings.ToUpper(mi.Method), url)}
+	if len(mi.QueryParams) > 0 {
+		query := make([]string, 0)
+		for _, param := range mi.QueryParams {
+			query = append(query, fmt.Sprintf("%q: %s", param.Json, pyName(param.Json)))
+		}
+		lines = append(lines, fmt.Sprintf("    params=_query({%s}),", strings.Join(query, ", ")))
+	}
+	if HasRequestBody(mi) {
+		lines = append(lines, fmt.Sprintf("    json=_encode(%s),", pyName(mi.BodyParam.Json)))
+	}
+	if mi.FileParam != nil {
+		lines = append(lines, fmt.Sprintf("    files={\"fileKey\": %s},", pyName(mi.FileParam.Json)))
+	}
+	lines = append(lines, "    headers=self.headers)", "response.raise_for_status()")
+
+	returnType := p.pyReturnType(mi)
+	switch returnType {
+	case "None":
+		lines = append(lines, "return None")
+	case "bytes":
+		lines = append(lines, "return response.content")
+	case "Any":
+		lines = append(lines, "return response.json()")
+	default:
+		lines = append(lines, fmt.Sprintf("return TypeAdapter(%s).validate_python(response.json())", returnType))
+	}
+	return strings.Join(lines, "\n        ")
+}
+
+// convert name to Python identifier (snake case, not a reserved word)
+func pyName(name string) string {
+	result := toSnakeCase(name)
+	if pythonKeywords[result] {
+		result += "_"
+	}
+	return result
+}
+
+// convert method name to Python method name
+func pyMethodName(name string) string {
+	return pyName(name)
+}
+
+// Build class level doc string from documentation lines (fallback to the provided name)
+func pyDocString(docs []string, fallback string) string {
+	return pyIndentedDocString(docs, fallback, "    ")
+}
+
+// Build method level doc string from documentation lines (fallback to the provided name)
+func pyMethodDoc(docs []string, fallback string) string {
+	return pyIndentedDocString(docs, fallback, "        ")
+}
+
+func pyIndentedDocString(docs []string, fallback string, indent string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		text = fallback
+	}
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, `"""`, `\"\"\"`)
+	text = strings.ReplaceAll(text, "\n", "\n"+indent)
+	return fmt.Sprintf(`%s"""%s"""`, indent, text)
+}
+
+// Build single line comment from documentation lines
+func pyComment(docs []string) string {
+	return strings.ReplaceAll(joinDocs(docs), "\n", " ")
+}
+
+// region Python templates ---------------------------------------------------------------------------------------------
+
+var pythonModelsTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+from __future__ import annotations
+
+from enum import IntEnum
+from typing import Any, Dict, Generic, List, Optional, TypeVar
+
+from pydantic import BaseModel, ConfigDict, Field
+
+{{range .TypeVars}}{{.}} = TypeVar("{{.}}")
+{{end}}
+
+{{range .Enums}}
+class {{.Name}}(IntEnum):
+{{pyDocString (deprecatedDocs .Docs .Deprecated) .Name}}
+{{range .Values}}
+    {{.Name}} = {{.Value}}{{with pyComment (deprecatedDocs .Docs .Deprecated)}}  # {{.}}{{end}}{{end}}
+
+{{end}}
+{{range .Classes}}{{$class := .}}
+class {{.Name}}({{pyClassBases .}}):
+{{pyDocString (deprecatedDocs .Docs .Deprecated) .Name}}
+
+    model_config = ConfigDict(populate_by_name=True)
+{{range .Fields}}
+    {{pyName .Name}}: Optional[{{pyFieldType $class .}}] = Field(default=None, alias={{pyQuote .Json}}{{with joinDocs (deprecatedDocs .Docs .Deprecated)}}, description={{pyQuote .}}{{end}}){{end}}
+
+{{end}}
+{{range .Classes}}{{.Name}}.model_rebuild()
+{{end}}`
+
+var pythonServicesTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+from __future__ import annotations
+
+from typing import Any, Dict, List, Optional
+from urllib.parse import quote
+
+import httpx
+from pydantic import BaseModel, TypeAdapter
+
+from .models import *  # noqa: F401,F403
+
+
+def _encode(value: Any) -> Any:
+    """Convert models (and lists of models) to JSON compatible values"""
+    if isinstance(value, BaseModel):
+        return value.model_dump(mode="json", by_alias=True, exclude_none=True)
+    if isinstance(value, list):
+        return [_encode(v) for v in value]
+    return value
+
+
+def _format(value: Any) -> str:
+    """Format single query parameter value"""
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, int):
+        return str(int(value))
+    return str(value)
+
+
+def _query(params: Dict[str, Any]) -> Dict[str, str]:
+    """Remove empty query parameters and join list values with comma"""
+    result = {}
+    for key, value in params.items():
+        if value is None:
+            continue
+        if isinstance(value, list):
+            result[key] = ",".join(_format(v) for v in value)
+        else:
+            result[key] = _format(value)
+    return result
+
+{{range .}}{{$service := .}}
+class {{.TsName}}:
+{{pyDocString (deprecatedDocs .Docs .Deprecated) .TsName}}
+
+    def __init__(self, base_url: str, client: Optional[httpx.Client] = None, headers: Optional[Dict[str, str]] = None) -> None:
+        """Create the client, headers are sent with every request{{with .Headers}} (expected: {{join . ", "}}){{end}}"""
+        self.base_url = base_url.rstrip("/") + {{pyQuote .Path}}
+        self.client = client if client is not None else httpx.Client()
+        self.headers = headers if headers is not None else {}
+{{range .Methods}}
+    def {{pyMethodName .Name}}({{pyMethodParams .}}) -> {{pyReturnType .}}:
+{{pyMethodDoc (deprecatedDocs .Docs (methodDeprecation $service .)) .Name}}
+        {{pyMethodBody .}}
+{{end}}
+{{end}}`
+
+var pythonInitTemplate = `# Code generated by yaaf-code-gen. DO NOT EDIT.
+from .models import *  # noqa: F401,F403
+from .services import *  # noqa: F401,F403
+`
+
+// endregion
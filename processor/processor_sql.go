@@ -0,0 +1,349 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var sqlTypes = map[string]string{
+	"double":        "DOUBLE PRECISION",
+	"float":         "DOUBLE PRECISION",
+	"float32":       "REAL",
+	"float64":       "DOUBLE PRECISION",
+	"int":           "BIGINT",
+	"int32":         "INTEGER",
+	"int64":         "BIGINT",
+	"uint":          "BIGINT",
+	"uint32":        "BIGINT",
+	"uint64":        "BIGINT",
+	"sint":          "BIGINT",
+	"sint32":        "INTEGER",
+	"sint64":        "BIGINT",
+	"fixed32":       "BIGINT",
+	"fixed64":       "BIGINT",
+	"sfixed32":      "INTEGER",
+	"sfixed64":      "BIGINT",
+	"bool":          "BOOLEAN",
+	"string":        "TEXT",
+	"bytes":         "BYTEA",
+	"any":           "JSONB",
+	"Timestamp":     "BIGINT",
+	"Json":          "JSONB",
+	"StreamContent": "BYTEA",
+	"number":        "DOUBLE PRECISION",
+	"boolean":       "BOOLEAN",
+}
+
+// PostgreSQL reserved words which must be quoted when used as identifiers
+var sqlKeywords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+	"asc": true, "both": true, "case": true, "cast": true, "check": true, "collate": true, "column": true,
+	"constraint": true, "create": true, "current_date": true, "current_role": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "default": true, "deferrable": true, "desc": true,
+	"distinct": true, "do": true, "else": true, "end": true, "except": true, "false": true, "fetch": true,
+	"for": true, "foreign": true, "from": true, "grant": true, "group": true, "having": true, "in": true,
+	"initially": true, "intersect": true, "into": true, "lateral": true, "leading": true, "limit": true,
+	"localtime": true, "localtimestamp": true, "not": true, "null": true, "offset": true, "on": true,
+	"only": true, "or": true, "order": true, "placing": true, "primary": true, "references": true,
+	"returning": true, "select": true, "session_user": true, "some": true, "symmetric": true, "table": true,
+	"then": true, "to": true, "trailing": true, "true": true, "union": true, "unique": true, "user": true,
+	"using": true, "variadic": true, "when": true, "where": true, "window": true, "with": true,
+}
+
+var sqlPlainIdent = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// SqlProcessor - SQL processor converts the model entities (classes with @Entity annotation) to PostgreSQL DDL
+type SqlProcessor struct {
+	BaseProcessor
+}
+
+// NewSqlProcessor - Factory method
+func NewSqlProcessor(model *model.MetaModel, output string) *SqlProcessor {
+	return &SqlProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+// sqlTable is the table definition of entity
+type sqlTable struct {
+	Name       string       `json:"name"`
+	Entity     string       `json:"entity"`
+	Docs       []string     `json:"docs,omitempty"`
+	Columns    []*sqlColumn `json:"columns"`
+	PrimaryKey []string     `json:"primaryKey,omitempty"`
+	Indexes    []*sqlIndex  `json:"indexes,omitempty"`
+}
+
+// sqlColumn is the column definition of entity field
+type sqlColumn struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	NotNull bool     `json:"notNull,omitempty"`
+	Check   string   `json:"check,omitempty"`
+	Docs    []string `json:"docs,omitempty"`
+}
+
+// sqlIndex is the index definition, fields with the same index name share one index
+type sqlIndex struct {
+	Name    string   `json:"name"`
+	Unique  bool     `json:"unique,omitempty"`
+	Columns []string `json:"columns"`
+}
+
+// Start the processor
+func (p *SqlProcessor) Start() error {
+	tmpl, err := parseSqlTemplate("schema.sql", sqlSchemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, buildSqlTables(p.Model)); err != nil {
+		return fmt.Errorf("error executing template [schema.sql]: %s", err.Error())
+	}
+	return p.writeFile(path.Join(p.Output, "schema.sql"), p.trimNewLines(tpl.String()))
+}
+
+// Parse SQL template together with the shared table definition template
+func parseSqlTemplate(name, source string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"sqlIdent":        sqlIdent,
+		"sqlColumnDef":    sqlColumnDef,
+		"sqlIndexDef":     sqlIndexDef,
+		"sqlComments":     sqlComments,
+		"sqlJoinIdents":   sqlJoinIdents,
+		"sqlColumnsWidth": sqlColumnsWidth,
+		"docs":            func(docs []string) string { return strings.Join(strings.Fields(joinDocs(docs)), " ") },
+		"last":            func(i int, n int) bool { return i == n-1 },
+	}
+
+	tmpl, err := template.New(name).Funcs(funcMap).Parse(sqlTableTemplate)
+	if err == nil {
+		tmpl, err = tmpl.Parse(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template [%s]: %s", name, err.Error())
+	}
+	return tmpl, nil
+}
+
+// Build table definitions of all the entities (sorted by table name)
+func buildSqlTables(mm *model.MetaModel) []*sqlTable {
+	tables := make([]*sqlTable, 0)
+	for _, class := range mm.ListClasses() {
+		if len(class.TableName) > 0 {
+			tables = append(tables, buildSqlTable(mm, class))
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables
+}
+
+// Build table definition of entity, when no field is annotated with @PrimaryKey the id column is the primary key
+func buildSqlTable(mm *model.MetaModel, class *model.ClassInfo) *sqlTable {
+	table := &sqlTable{Name: class.TableName, Entity: class.Name, Docs: class.Docs}
+
+	indexes := make(map[string]*sqlIndex)
+	addIndex := func(name string, unique bool, column string) {
+		if len(name) == 0 {
+			suffix := "idx"
+			if unique {
+				suffix = "key"
+			}
+			name = fmt.Sprintf("%s_%s_%s", sqlNamePart(table.Name), column, suffix)
+		}
+		if idx, ok := indexes[name]; ok {
+			idx.Columns = append(idx.Columns, column)
+			idx.Unique = idx.Unique || unique
+			return
+		}
+		idx := &sqlIndex{Name: name, Unique: unique, Columns: []string{column}}
+		indexes[name] = idx
+		table.Indexes = append(table.Indexes, idx)
+	}
+
+	var idColumn *sqlColumn
+	for _, field := range mm.ListClassFields(class) {
+		column := &sqlColumn{Name: sqlNamePart(toSnakeCase(field.Json)), Docs: field.Docs}
+		column.Type, column.Check = sqlColumnType(mm, field, column.Name)
+		column.NotNull = field.IsNotNull || field.IsPrimaryKey
+		table.Columns = append(table.Columns, column)
+
+		if field.IsPrimaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.Name)
+		}
+		if column.Name == "id" {
+			idColumn = column
+		}
+		for _, name := range field.Indexes {
+			addIndex(name, false, column.Name)
+		}
+		for _, name := range field.Uniques {
+			addIndex(name, true, column.Name)
+		}
+	}
+
+	if len(table.PrimaryKey) == 0 && idColumn != nil {
+		idColumn.NotNull = true
+		table.PrimaryKey = []string{idColumn.Name}
+	}
+	return table
+}
+
+// Get the SQL column type and check constraint of field (enums are stored as integers)
+func sqlColumnType(mm *model.MetaModel, field *model.FieldInfo, column string) (string, string) {
+	node := model.NewTypeNodeFromGoType(field.Type)
+	if node == nil || field.IsMap {
+		return "JSONB", ""
+	}
+	isArray := field.IsArray || node.IsArray
+	if field.IsArray && node.IsArray {
+		return "JSONB", ""
+	}
+
+	if sqlType, ok := sqlTypes[node.Name]; ok {
+		if isArray && sqlType != "JSONB" {
+			return sqlType + "[]", ""
+		}
+		return sqlType, ""
+	}
+
+	if enum := mm.GetEnum(node.Name); enum != nil {
+		values := make([]string, 0)
+		seen := make(map[int]bool)
+		for _, ev := range enum.Values {
+			if !seen[ev.Value] {
+				seen[ev.Value] = true
+				values = append(values, strconv.Itoa(ev.Value))
+			}
+		}
+		check := ""
+		if isArray {
+			if len(values) > 0 {
+				check = fmt.Sprintf("%s <@ ARRAY[%s]", sqlIdent(column), strings.Join(values, ", "))
+			}
+			return "INTEGER[]", check
+		}
+		if len(values) > 0 {
+			check = fmt.Sprintf("%s IN (%s)", sqlIdent(column), strings.Join(values, ", "))
+		}
+		return "INTEGER", check
+	}
+
+	// Nested structures, generic types and unknown types are stored as JSON documents
+	return "JSONB", ""
+}
+
+// Quote identifier when required
+func sqlIdent(name string) string {
+	if sqlPlainIdent.MatchString(name) && !sqlKeywords[name] {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Convert name to lower case identifier part (non alphanumeric characters are replaced with underscore)
+func sqlNamePart(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return '_'
+	}, name)
+}
+
+// Quote SQL string literal
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Join list of identifiers
+func sqlJoinIdents(names []string) string {
+	list := make([]string, 0)
+	for _, name := range names {
+		list = append(list, sqlIdent(name))
+	}
+	return strings.Join(list, ", ")
+}
+
+// Get the width of the column names for aligned column definitions
+func sqlColumnsWidth(columns []*sqlColumn) int {
+	width := 0
+	for _, column := range columns {
+		if n := len(sqlIdent(column.Name)); n > width {
+			width = n
+		}
+	}
+	return width
+}
+
+// Build column definition (without trailing comma)
+func sqlColumnDef(column *sqlColumn, width int) string {
+	def := fmt.Sprintf("%-*s %s", width, sqlIdent(column.Name), column.Type)
+	if column.NotNull {
+		def += " NOT NULL"
+	}
+	if len(column.Check) > 0 {
+		def += fmt.Sprintf(" CHECK (%s)", column.Check)
+	}
+	return def
+}
+
+// Build create index statement
+func sqlIndexDef(table string, idx *sqlIndex) string {
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s (%s);", unique, sqlIdent(idx.Name), sqlIdent(table), sqlJoinIdents(idx.Columns))
+}
+
+// Build comment statements of table and its columns
+func sqlComments(table *sqlTable) string {
+	lines := make([]string, 0)
+	if text := joinDocs(table.Docs); len(text) > 0 {
+		lines = append(lines, fmt.Sprintf("COMMENT ON TABLE %s IS %s;", sqlIdent(table.Name), sqlString(text)))
+	}
+	for _, column := range table.Columns {
+		if text := joinDocs(column.Docs); len(text) > 0 {
+			lines = append(lines, sqlColumnComment(table.Name, column.Name, text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Build comment statement of single column
+func sqlColumnComment(table, column, text string) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", sqlIdent(table), sqlIdent(column), sqlString(text))
+}
+
+// region SQL template -------------------------------------------------------------------------------------------------
+
+var sqlSchemaTemplate = `-- Code generated by yaaf-code-gen. DO NOT EDIT.
+{{range .}}
+{{template "table" .}}{{end}}`
+
+var sqlTableTemplate = `{{define "table"}}{{$table := .}}{{$width := sqlColumnsWidth .Columns}}{{$n := len .Columns}}-- {{.Entity}}{{with docs .Docs}}: {{.}}{{end}}
+CREATE TABLE IF NOT EXISTS {{sqlIdent .Name}} (
+{{range $i, $c := .Columns}}    {{sqlColumnDef $c $width}}{{if or $table.PrimaryKey (not (last $i $n))}},{{end}}
+{{end}}{{with .PrimaryKey}}    PRIMARY KEY ({{sqlJoinIdents .}})
+{{end}});
+{{range .Indexes}}{{sqlIndexDef $table.Name .}}
+{{end}}{{with sqlComments .}}{{.}}
+{{end}}{{end}}`
+
+// endregion
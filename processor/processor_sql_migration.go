@@ -0,0 +1,354 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// SqlSnapshotFile is the name of the file (in the output folder) which keeps the tables definition of the last migration
+const SqlSnapshotFile = "schema.snapshot.json"
+
+var sqlMigrationFile = regexp.MustCompile(`^(\d+)_migration\.sql$`)
+
+// SqlMigrationProcessor - SQL migration processor compares the model entities with the snapshot of the previous run
+// and generates forward migration script (and report of destructive changes)
+type SqlMigrationProcessor struct {
+	BaseProcessor
+}
+
+// NewSqlMigrationProcessor - Factory method
+func NewSqlMigrationProcessor(model *model.MetaModel, output string) *SqlMigrationProcessor {
+	return &SqlMigrationProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+	}
+}
+
+// sqlMigration is the template data of migration script
+type sqlMigration struct {
+	Version    string
+	Statements []string
+	Warnings   []string
+}
+
+// Start the processor
+func (p *SqlMigrationProcessor) Start() error {
+
+	snapshotFile := path.Join(p.Output, SqlSnapshotFile)
+	prev, err := p.readSnapshot(snapshotFile)
+	if err != nil {
+		return err
+	}
+	curr := buildSqlTables(p.Model)
+
+	version, err := p.nextVersion()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := parseSqlTemplate("migration.sql", sqlMigrationTemplate)
+	if err != nil {
+		return err
+	}
+
+	migration := &sqlMigration{Version: fmt.Sprintf("%04d", version)}
+	if err = diffSqlTables(tmpl, migration, prev, curr); err != nil {
+		return err
+	}
+
+	// Nothing changed since the previous run
+	if len(migration.Statements) == 0 {
+		return nil
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, migration); err != nil {
+		return fmt.Errorf("error executing template [migration.sql]: %s", err.Error())
+	}
+	if err = p.writeFile(path.Join(p.Output, fmt.Sprintf("%s_migration.sql", migration.Version)), p.trimNewLines(tpl.String())); err != nil {
+		return err
+	}
+
+	if len(migration.Warnings) > 0 {
+		tpl.Reset()
+		if err = tmpl.ExecuteTemplate(&tpl, "report", migration); err != nil {
+			return fmt.Errorf("error executing template [report]: %s", err.Error())
+		}
+		if err = p.writeFile(path.Join(p.Output, fmt.Sprintf("%s_migration.report.md", migration.Version)), tpl.String()); err != nil {
+			return err
+		}
+	}
+	return p.writeSnapshot(snapshotFile, curr)
+}
+
+// Read the tables definition of the previous run, missing snapshot means empty database
+func (p *SqlMigrationProcessor) readSnapshot(fileName string) ([]*sqlTable, error) {
+	tables := make([]*sqlTable, 0)
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return tables, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &tables); err != nil {
+		return nil, fmt.Errorf("error reading snapshot file [%s]: %s", fileName, err.Error())
+	}
+	return tables, nil
+}
+
+// Write the tables definition snapshot
+func (p *SqlMigrationProcessor) writeSnapshot(fileName string, tables []*sqlTable) error {
+	data, err := json.MarshalIndent(tables, "", "  ")
+	if err != nil {
+		return err
+	}
+	return p.writeFile(fileName, string(data)+"\n")
+}
+
+// Get the next migration version number based on the existing migration files
+func (p *SqlMigrationProcessor) nextVersion() (int, error) {
+	entries, err := os.ReadDir(p.Output)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 1, nil
+		}
+		return 0, err
+	}
+	version := 0
+	for _, entry := range entries {
+		if match := sqlMigrationFile.FindStringSubmatch(entry.Name()); match != nil {
+			if n, _ := strconv.Atoi(match[1]); n > version {
+				version = n
+			}
+		}
+	}
+	return version + 1, nil
+}
+
+// Compare the previous and current tables definition and add migration statements and warnings:
+// renamed tables first, then new and altered tables and dropped tables last
+func diffSqlTables(tmpl *template.Template, migration *sqlMigration, prev, curr []*sqlTable) error {
+	prevTables := make(map[string]*sqlTable)
+	for _, table := range prev {
+		prevTables[table.Name] = table
+	}
+	currTables := make(map[string]*sqlTable)
+	for _, table := range curr {
+		currTables[table.Name] = table
+	}
+
+	// Entity with new table name is renamed (when the previous table is not used by another entity)
+	renamed := make(map[string]string)
+	for _, table := range curr {
+		if _, exists := prevTables[table.Name]; exists {
+			continue
+		}
+		for _, old := range prev {
+			if _, used := currTables[old.Name]; !used && old.Entity == table.Entity && len(renamed[old.Name]) == 0 {
+				renamed[old.Name] = table.Name
+				migration.add(fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", sqlIdent(old.Name), sqlIdent(table.Name)))
+				migration.warn("`%s`: table renamed to `%s`, indexes are recreated", old.Name, table.Name)
+				prevTables[table.Name] = old
+			}
+		}
+	}
+
+	for _, table := range curr {
+		if old, exists := prevTables[table.Name]; exists {
+			diffSqlTable(migration, old, table)
+			continue
+		}
+		var tpl bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&tpl, "table", table); err != nil {
+			return fmt.Errorf("error executing template [table]: %s", err.Error())
+		}
+		migration.add(strings.TrimSpace(tpl.String()))
+	}
+
+	for _, table := range prev {
+		if _, exists := currTables[table.Name]; !exists && len(renamed[table.Name]) == 0 {
+			migration.add(fmt.Sprintf("DROP TABLE IF EXISTS %s;", sqlIdent(table.Name)))
+			migration.warn("`%s`: table dropped, all the table data is lost", table.Name)
+		}
+	}
+	return nil
+}
+
+// Compare the previous and current definition of the same table
+func diffSqlTable(migration *sqlMigration, prev, curr *sqlTable) {
+	table := sqlIdent(curr.Name)
+	prevColumns := make(map[string]*sqlColumn)
+	for _, column := range prev.Columns {
+		prevColumns[column.Name] = column
+	}
+	currColumns := make(map[string]*sqlColumn)
+	for _, column := range curr.Columns {
+		currColumns[column.Name] = column
+	}
+	prevIndexes := make(map[string]*sqlIndex)
+	for _, idx := range prev.Indexes {
+		prevIndexes[idx.Name] = idx
+	}
+	currIndexes := make(map[string]*sqlIndex)
+	for _, idx := range curr.Indexes {
+		currIndexes[idx.Name] = idx
+	}
+	renamed := prev.Name != curr.Name
+
+	// Drop removed and changed indexes (indexes of renamed table are recreated with the new names)
+	for _, idx := range prev.Indexes {
+		if ci, exists := currIndexes[idx.Name]; renamed || !exists || !reflect.DeepEqual(idx, ci) {
+			migration.add(fmt.Sprintf("DROP INDEX IF EXISTS %s;", sqlIdent(idx.Name)))
+		}
+	}
+
+	pkChanged := !reflect.DeepEqual(prev.PrimaryKey, curr.PrimaryKey)
+	if pkChanged && len(prev.PrimaryKey) > 0 {
+		migration.add(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", table, sqlIdent(sqlNamePart(prev.Name)+"_pkey")))
+	}
+
+	for _, column := range curr.Columns {
+		old, exists := prevColumns[column.Name]
+		if !exists {
+			migration.add(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, sqlColumnDef(column, 0)))
+			if column.NotNull {
+				migration.warn("`%s.%s`: new NOT NULL column without default value, fails when the table is not empty", curr.Name, column.Name)
+			}
+			if text := joinDocs(column.Docs); len(text) > 0 {
+				migration.add(sqlColumnComment(curr.Name, column.Name, text))
+			}
+			continue
+		}
+		diffSqlColumn(migration, prev.Name, curr.Name, old, column)
+	}
+
+	for _, column := range prev.Columns {
+		if _, exists := currColumns[column.Name]; !exists {
+			migration.add(fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;", table, sqlIdent(column.Name)))
+			migration.warn("`%s.%s`: column dropped, the column data is lost (renamed fields appear as dropped and added columns)", curr.Name, column.Name)
+		}
+	}
+
+	if pkChanged && len(curr.PrimaryKey) > 0 {
+		migration.add(fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", table, sqlJoinIdents(curr.PrimaryKey)))
+		migration.warn("`%s`: primary key changed to (%s), fails when the existing rows are not unique", curr.Name, strings.Join(curr.PrimaryKey, ", "))
+	}
+
+	// Create new and changed indexes
+	for _, idx := range curr.Indexes {
+		if pi, exists := prevIndexes[idx.Name]; renamed || !exists || !reflect.DeepEqual(idx, pi) {
+			migration.add(sqlIndexDef(curr.Name, idx))
+			if idx.Unique && !sqlHasUniqueIndex(prev, idx.Columns) {
+				migration.warn("`%s`: unique index `%s` created, fails when the existing rows are not unique", curr.Name, idx.Name)
+			}
+		}
+	}
+
+	if prevText, currText := joinDocs(prev.Docs), joinDocs(curr.Docs); prevText != currText {
+		migration.add(fmt.Sprintf("COMMENT ON TABLE %s IS %s;", table, sqlCommentText(currText)))
+	}
+}
+
+// Compare the previous and current definition of the same column (the table name is different when the table is renamed)
+func diffSqlColumn(migration *sqlMigration, prevTableName, tableName string, prev, curr *sqlColumn) {
+	table := sqlIdent(tableName)
+	column := sqlIdent(curr.Name)
+
+	// The check constraint is dropped before the type is changed and added after it
+	checkChanged := prev.Check != curr.Check || prev.Type != curr.Type
+	if checkChanged && len(prev.Check) > 0 {
+		migration.add(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", table, sqlCheckName(prevTableName, curr.Name)))
+	}
+
+	if prev.Type != curr.Type {
+		migration.add(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", table, column, curr.Type, column, curr.Type))
+		migration.warn("`%s.%s`: type changed from %s to %s, fails or loses data when the existing values can't be converted", tableName, curr.Name, prev.Type, curr.Type)
+	}
+
+	if prev.NotNull != curr.NotNull {
+		if curr.NotNull {
+			migration.add(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, column))
+			migration.warn("`%s.%s`: column changed to NOT NULL, fails when the existing rows have null values", tableName, curr.Name)
+		} else {
+			migration.add(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", table, column))
+		}
+	}
+
+	if checkChanged && len(curr.Check) > 0 {
+		migration.add(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);", table, sqlCheckName(tableName, curr.Name), curr.Check))
+		if len(prev.Check) > 0 && prev.Check != curr.Check {
+			migration.warn("`%s.%s`: allowed values changed to (%s), fails when the existing rows have other values", tableName, curr.Name, curr.Check)
+		}
+	}
+
+	if prevText, currText := joinDocs(prev.Docs), joinDocs(curr.Docs); prevText != currText {
+		migration.add(fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, column, sqlCommentText(currText)))
+	}
+}
+
+// Check if the table has unique index on the columns
+func sqlHasUniqueIndex(table *sqlTable, columns []string) bool {
+	for _, idx := range table.Indexes {
+		if idx.Unique && reflect.DeepEqual(idx.Columns, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the name of column check constraint (the default name PostgreSQL gives to column check constraint)
+func sqlCheckName(table, column string) string {
+	return sqlIdent(fmt.Sprintf("%s_%s_check", sqlNamePart(table), column))
+}
+
+// Comment text literal (empty comment removes the comment)
+func sqlCommentText(text string) string {
+	if len(text) == 0 {
+		return "NULL"
+	}
+	return sqlString(text)
+}
+
+// Add migration statement
+func (m *sqlMigration) add(statement string) {
+	m.Statements = append(m.Statements, statement)
+}
+
+// Add warning about destructive change (or change which may fail)
+func (m *sqlMigration) warn(format string, args ...any) {
+	m.Warnings = append(m.Warnings, fmt.Sprintf(format, args...))
+}
+
+// region SQL migration templates --------------------------------------------------------------------------------------
+
+var sqlMigrationTemplate = `-- Code generated by yaaf-code-gen. Review before applying.
+-- Migration {{.Version}}
+{{if .Warnings}}-- WARNING: this migration includes destructive changes, see {{.Version}}_migration.report.md
+{{end}}
+BEGIN;
+{{range .Statements}}
+{{.}}
+{{end}}
+COMMIT;
+{{define "report"}}# Migration {{.Version}} warnings
+
+The migration includes the following destructive changes (or changes which may fail on existing data):
+
+{{range .Warnings}}- {{.}}
+{{end}}{{end}}`
+
+// endregion
@@ -0,0 +1,544 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+var swiftTypes = map[string]string{
+	"double":        "Double",
+	"float":         "Double",
+	"float32":       "Float",
+	"float64":       "Double",
+	"int":           "Int",
+	"int32":         "Int32",
+	"int64":         "Int64",
+	"uint":          "UInt",
+	"uint32":        "UInt32",
+	"uint64":        "UInt64",
+	"sint":          "Int",
+	"sint32":        "Int32",
+	"sint64":        "Int64",
+	"fixed32":       "UInt32",
+	"fixed64":       "UInt64",
+	"sfixed32":      "Int32",
+	"sfixed64":      "Int64",
+	"bool":          "Bool",
+	"string":        "String",
+	"bytes":         "Data",
+	"any":           "JSONValue",
+	"Timestamp":     "Int64",
+	"Json":          "[String: JSONValue]",
+	"StreamContent": "Data",
+	"number":        "Double",
+	"boolean":       "Bool",
+}
+
+var swiftKeywords = map[string]bool{
+	"associatedtype": true, "class": true, "deinit": true, "enum": true, "extension": true, "fileprivate": true,
+	"func": true, "import": true, "init": true, "inout": true, "internal": true, "let": true, "open": true,
+	"operator": true, "private": true, "protocol": true, "public": true, "rethrows": true, "static": true,
+	"struct": true, "subscript": true, "typealias": true, "var": true, "break": true, "case": true,
+	"continue": true, "default": true, "defer": true, "do": true, "else": true, "fallthrough": true,
+	"for": true, "guard": true, "if": true, "in": true, "repeat": true, "return": true, "switch": true,
+	"where": true, "while": true, "as": true, "catch": true, "false": true, "is": true, "nil": true,
+	"self": true, "Self": true, "super": true, "throw": true, "throws": true, "true": true, "try": true,
+	"Type": true, "Protocol": true,
+}
+
+// SwiftProcessor - Swift processor converts the meta model to Swift package (Codable models and async URLSession clients)
+type SwiftProcessor struct {
+	BaseProcessor
+	Module string // Swift module (package) name
+}
+
+// NewSwiftProcessor - Factory method
+func NewSwiftProcessor(model *model.MetaModel, output string, module string) *SwiftProcessor {
+	return &SwiftProcessor{
+		BaseProcessor: BaseProcessor{
+			Output: output,
+			Model:  model,
+		},
+		Module: module,
+	}
+}
+
+// Start the processor
+func (p *SwiftProcessor) Start() error {
+
+	funcMap := template.FuncMap{
+		"swDocs":         swDocs,
+		"swDeprecated":   swDeprecated,
+		"swName":         swName,
+		"swCaseName":     swCaseName,
+		"swQuote":        strconv.Quote,
+		"swGenerics":     swGenerics,
+		"swFields":       p.swFields,
+		"swFieldType":    p.swFieldType,
+		"swEnumValues":   swEnumValues,
+		"swMethodParams": p.swMethodParams,
+		"swReturnType":   p.swReturnType,
+		"swMethodBody":   p.swMethodBody,
+		"join":           strings.Join,
+		"last":           func(i int, n int) bool { return i == n-1 },
+	}
+
+	sources := path.Join(p.Output, "Sources", p.Module)
+
+	// Generate package manifest and runtime support
+	if err := p.generate(path.Join(p.Output, "Package.swift"), swiftPackageTemplate, funcMap, p.Module); err != nil {
+		return err
+	}
+	if err := p.generate(path.Join(sources, "APIClient.swift"), swiftClientTemplate, funcMap, p.Module); err != nil {
+		return err
+	}
+
+	// Generate all enums
+	for _, enum := range p.Model.ListEnums() {
+		fileName := path.Join(sources, "Models", fmt.Sprintf("%s.swift", enum.Name))
+		if err := p.generate(fileName, swiftEnumTemplate, funcMap, enum); err != nil {
+			return err
+		}
+	}
+
+	// Generate all classes
+	for _, class := range p.Model.ListClasses() {
+		if class.IsParam {
+			continue
+		}
+		fileName := path.Join(sources, "Models", fmt.Sprintf("%s.swift", class.Name))
+		if err := p.generate(fileName, swiftStructTemplate, funcMap, class); err != nil {
+			return err
+		}
+	}
+
+	// Generate all services
+	for _, service := range p.Model.ListServices() {
+		fileName := path.Join(sources, "Services", fmt.Sprintf("%s.swift", service.TsName))
+		if err := p.generate(fileName, swiftServiceTemplate, funcMap, service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute template and write the result to file
+func (p *SwiftProcessor) generate(fileName, source string, funcMap template.FuncMap, data any) error {
+	name := path.Base(fileName)
+	tmpl, err := template.New(name).Funcs(funcMap).Parse(source)
+	if err != nil {
+		return fmt.Errorf("error parsing template [%s]: %s", name, err.Error())
+	}
+
+	var tpl bytes.Buffer
+	if err = tmpl.Execute(&tpl, data); err != nil {
+		return fmt.Errorf("error executing template [%s]: %s", name, err.Error())
+	}
+	return p.writeFile(fileName, p.trimNewLines(tpl.String()))
+}
+
+// List struct fields, structs can't be extended so the base class fields are flattened
+func (p *SwiftProcessor) swFields(class *model.ClassInfo) []*model.FieldInfo {
+	return p.Model.ListClassFields(class)
+}
+
+// Get the Swift type of class field
+func (p *SwiftProcessor) swFieldType(class *model.ClassInfo, field *model.FieldInfo) string {
+	swType := p.swType(model.NewTypeNodeFromGoType(field.Type), class.GenericTypes)
+	if field.IsArray {
+		swType = fmt.Sprintf("[%s]", swType)
+	}
+	return swType
+}
+
+// Get the Swift type of type node
+func (p *SwiftProcessor) swType(node *model.TypeNode, generics []model.StringKeyValue) string {
+	if node == nil {
+		return "JSONValue"
+	}
+
+	result := "JSONValue"
+	if swType, ok := swiftTypes[node.Name]; ok {
+		result = swType
+	} else if p.Model.GetEnum(node.Name) != nil {
+		result = node.Name
+	} else if class := p.Model.GetClass(node.Name); class != nil {
+		result = node.Name
+		if class.IsGeneric && len(node.Args) > 0 {
+			args := make([]string, 0)
+			for _, arg := range node.Args {
+				args = append(args, p.swType(arg, generics))
+			}
+			result = fmt.Sprintf("%s<%s>", node.Name, strings.Join(args, ", "))
+		}
+	} else {
+		for _, gt := range generics {
+			if gt.Key == node.Name {
+				result = node.Name
+			}
+		}
+	}
+
+	if node.IsArray {
+		result = fmt.Sprintf("[%s]", result)
+	}
+	return result
+}
+
+// Get the Swift type of method parameter
+func (p *SwiftProcessor) swParamType(param *model.ParamInfo) string {
+	if param.ParamType == "file" {
+		return "Data"
+	}
+	swType := p.swType(model.NewTypeNode(param.Type), nil)
+	if param.IsArray {
+		swType = fmt.Sprintf("[%s]", swType)
+	}
+	return swType
+}
+
+// Build method input parameters list (path parameters are required, the rest are optional)
+func (p *SwiftProcessor) swMethodParams(mi *model.MethodInfo) string {
+	params := make([]string, 0)
+	for _, param := range listMethodParams(*mi) {
+		switch param.ParamType {
+		case "path", "file":
+			params = append(params, fmt.Sprintf("%s: %s", swName(param.Json), p.swParamType(param)))
+		case "body":
+			if HasRequestBody(mi) {
+				params = append(params, fmt.Sprintf("%s: %s", swName(param.Json), p.swParamType(param)))
+			}
+		default:
+			params = append(params, fmt.Sprintf("%s: %s? = nil", swName(param.Json), p.swParamType(param)))
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// Get the method return type
+func (p *SwiftProcessor) swReturnType(mi *model.MethodInfo) string {
+	if mi.IsFileUpload {
+		return "URL"
+	}
+	if mi.ReturnType == nil {
+		return "Void"
+	}
+	return p.swType(mi.ReturnType, nil)
+}
+
+// Build method content - invoke the API client
+func (p *SwiftProcessor) swMethodBody(si *model.ServiceInfo, mi *model.MethodInfo) string {
+	url := mi.Path
+	if url == "/" {
+		url = ""
+	}
+	for _, param := range mi.PathParams {
+		url = strings.ReplaceAll(url, fmt.Sprintf("{%s}", param.Json), fmt.Sprintf(`\(APIClient.escape(%s))`, swName(param.Json)))
+	}
+	url = fmt.Sprintf(`"%s%s"`, si.Path, url)
+
+	// Upload handler returns the upload URL
+	if mi.IsFileUpload {
+		return fmt.Sprintf("return client.url(%s)", url)
+	}
+
+	args := []string{strconv.Quote(strings.ToUpper(mi.Method)), url}
+	if len(mi.QueryParams) > 0 {
+		query := make([]string, 0)
+		for _, param := range mi.QueryParams {
+			query = append(query, fmt.Sprintf("%q: %s", param.Json, swName(param.Json)))
+		}
+		args = append(args, fmt.Sprintf("query: [%s]", strings.Join(query, ", ")))
+	}
+	if HasRequestBody(mi) {
+		args = append(args, fmt.Sprintf("body: %s", swName(mi.BodyParam.Json)))
+	}
+	if mi.FileParam != nil {
+		args = append(args, fmt.Sprintf("file: %s", swName(mi.FileParam.Json)))
+	}
+	call := fmt.Sprintf("client.send(%s)", strings.Join(args, ", "))
+
+	switch p.swReturnType(mi) {
+	case "Void":
+		return fmt.Sprintf("_ = try await %s", call)
+	case "Data":
+		return fmt.Sprintf("return try await %s", call)
+	default:
+		return fmt.Sprintf("return try client.decode(try await %s)", call)
+	}
+}
+
+// List enum values with unique raw values (Swift does not allow duplicate raw values)
+func swEnumValues(enum *model.EnumInfo) []*model.EnumValueInfo {
+	list := make([]*model.EnumValueInfo, 0)
+	exists := make(map[int]bool)
+	for _, ev := range enum.Values {
+		if !exists[ev.Value] {
+			exists[ev.Value] = true
+			list = append(list, ev)
+		}
+	}
+	return list
+}
+
+// convert name to Swift identifier (escape reserved words)
+func swName(name string) string {
+	result := toCamelCase(name)
+	if swiftKeywords[result] {
+		result = fmt.Sprintf("`%s`", result)
+	}
+	return result
+}
+
+// convert enum value name (e.g. USER_ACTIVE) to Swift enum case name (e.g. userActive)
+func swCaseName(name string) string {
+	result := toEnumCaseName(name)
+	if swiftKeywords[result] {
+		result = fmt.Sprintf("`%s`", result)
+	}
+	return result
+}
+
+// Build generic type parameters list
+func swGenerics(class *model.ClassInfo) string {
+	if !class.IsGeneric || len(class.GenericTypes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0)
+	for _, gt := range class.GenericTypes {
+		keys = append(keys, fmt.Sprintf("%s: Codable", gt.Key))
+	}
+	return fmt.Sprintf("<%s>", strings.Join(keys, ", "))
+}
+
+// Build documentation comment from documentation lines
+func swDocs(docs []string, indent string) string {
+	text := joinDocs(docs)
+	if len(text) == 0 {
+		return ""
+	}
+	output := ""
+	for _, line := range strings.Split(text, "\n") {
+		output += fmt.Sprintf("%s/// %s\n", indent, line)
+	}
+	return output
+}
+
+// Build availability attribute of deprecated element
+func swDeprecated(deprecated *model.DeprecationInfo, indent string) string {
+	if deprecated == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s@available(*, deprecated, message: %s)\n", indent, strconv.Quote(deprecated.String()))
+}
+
+// region Swift templates ----------------------------------------------------------------------------------------------
+
+var swiftPackageTemplate = `// swift-tools-version:5.7
+// Code generated by yaaf-code-gen. DO NOT EDIT.
+import PackageDescription
+
+let package = Package(
+    name: "{{.}}",
+    platforms: [.iOS(.v15), .macOS(.v12)],
+    products: [
+        .library(name: "{{.}}", targets: ["{{.}}"]),
+    ],
+    targets: [
+        .target(name: "{{.}}"),
+    ]
+)
+`
+
+var swiftClientTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import Foundation
+#if canImport(FoundationNetworking)
+import FoundationNetworking
+#endif
+
+/// API error for non successful HTTP status codes
+public struct APIError: Error {
+    public let statusCode: Int
+    public let data: Data
+}
+
+/// Value which can be sent as path or query parameter
+public protocol QueryValue {
+    var queryValue: String { get }
+}
+
+extension String: QueryValue { public var queryValue: String { self } }
+extension Int: QueryValue { public var queryValue: String { String(self) } }
+extension Int32: QueryValue { public var queryValue: String { String(self) } }
+extension Int64: QueryValue { public var queryValue: String { String(self) } }
+extension UInt: QueryValue { public var queryValue: String { String(self) } }
+extension UInt32: QueryValue { public var queryValue: String { String(self) } }
+extension UInt64: QueryValue { public var queryValue: String { String(self) } }
+extension Double: QueryValue { public var queryValue: String { String(self) } }
+extension Float: QueryValue { public var queryValue: String { String(self) } }
+extension Bool: QueryValue { public var queryValue: String { self ? "true" : "false" } }
+
+// Lists are sent as comma separated values
+extension Array: QueryValue where Element: QueryValue {
+    public var queryValue: String { map { $0.queryValue }.joined(separator: ",") }
+}
+
+// Enums are sent as their numeric value
+extension QueryValue where Self: RawRepresentable, Self.RawValue == Int {
+    public var queryValue: String { String(rawValue) }
+}
+
+/// Any JSON value
+public enum JSONValue: Codable, Hashable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .null: try container.encodeNil()
+        case .bool(let value): try container.encode(value)
+        case .number(let value): try container.encode(value)
+        case .string(let value): try container.encode(value)
+        case .array(let value): try container.encode(value)
+        case .object(let value): try container.encode(value)
+        }
+    }
+}
+
+/// HTTP client shared by all the {{.}} services
+public final class APIClient {
+    public var baseURL: URL
+    public var headers: [String: String]
+    public let session: URLSession
+    public let encoder = JSONEncoder()
+    public let decoder = JSONDecoder()
+
+    public init(baseURL: URL, headers: [String: String] = [:], session: URLSession = .shared) {
+        self.baseURL = baseURL
+        self.headers = headers
+        self.session = session
+    }
+
+    /// Escape path parameter value
+    public static func escape(_ value: QueryValue) -> String {
+        value.queryValue.addingPercentEncoding(withAllowedCharacters: .urlPathAllowed.subtracting(CharacterSet(charactersIn: "/"))) ?? value.queryValue
+    }
+
+    /// Build the full URL of a resource path
+    public func url(_ path: String, query: [String: QueryValue?] = [:]) -> URL {
+        var base = baseURL.absoluteString
+        if base.hasSuffix("/") {
+            base.removeLast()
+        }
+        var components = URLComponents(string: base + path)!
+        let items = query.keys.sorted().compactMap { key in query[key]!.map { URLQueryItem(name: key, value: $0.queryValue) } }
+        if !items.isEmpty {
+            components.queryItems = items
+        }
+        return components.url!
+    }
+
+    /// Send request and return the response content
+    public func send(_ method: String, _ path: String, query: [String: QueryValue?] = [:], body: Encodable? = nil, file: Data? = nil) async throws -> Data {
+        var request = URLRequest(url: url(path, query: query))
+        request.httpMethod = method
+        headers.forEach { request.setValue($0.value, forHTTPHeaderField: $0.key) }
+
+        if let file = file {
+            let boundary = UUID().uuidString
+            var content = Data()
+            content.append("--\(boundary)\r\nContent-Disposition: form-data; name=\"fileKey\"; filename=\"file\"\r\n".data(using: .utf8)!)
+            content.append("Content-Type: application/octet-stream\r\n\r\n".data(using: .utf8)!)
+            content.append(file)
+            content.append("\r\n--\(boundary)--\r\n".data(using: .utf8)!)
+            request.setValue("multipart/form-data; boundary=\(boundary)", forHTTPHeaderField: "Content-Type")
+            request.httpBody = content
+        } else if let body = body {
+            request.setValue("application/json", forHTTPHeaderField: "Content-Type")
+            request.httpBody = try encoder.encode(body)
+        }
+
+        let (data, response) = try await session.data(for: request)
+        if let http = response as? HTTPURLResponse, !(200..<300).contains(http.statusCode) {
+            throw APIError(statusCode: http.statusCode, data: data)
+        }
+        return data
+    }
+
+    /// Decode response content
+    public func decode<T: Decodable>(_ data: Data) throws -> T {
+        try decoder.decode(T.self, from: data)
+    }
+}
+`
+
+var swiftEnumTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import Foundation
+
+{{swDocs .Docs ""}}{{swDeprecated .Deprecated ""}}public enum {{.Name}}: Int, Codable, CaseIterable, QueryValue {
+{{range swEnumValues .}}{{swDocs .Docs "    "}}{{swDeprecated .Deprecated "    "}}    case {{swCaseName .Name}} = {{.Value}}
+{{end}}}
+`
+
+var swiftStructTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import Foundation
+{{$class := .}}{{$fields := swFields .}}{{$n := len $fields}}
+{{swDocs .Docs ""}}{{swDeprecated .Deprecated ""}}public struct {{.Name}}{{swGenerics .}}: Codable {
+{{range $fields}}{{swDocs .Docs "    "}}{{swDeprecated .Deprecated "    "}}    public var {{swName .Name}}: {{swFieldType $class .}}?
+{{end}}{{if $fields}}
+    enum CodingKeys: String, CodingKey {
+{{range $fields}}        case {{swName .Name}} = {{swQuote .Json}}
+{{end}}    }
+{{end}}
+    public init({{range $i, $f := $fields}}{{swName $f.Name}}: {{swFieldType $class $f}}? = nil{{if not (last $i $n)}}, {{end}}{{end}}) {
+{{range $fields}}        self.{{swName .Name}} = {{swName .Name}}
+{{end}}    }
+}
+`
+
+var swiftServiceTemplate = `// Code generated by yaaf-code-gen. DO NOT EDIT.
+import Foundation
+{{$service := .}}
+{{swDocs .Docs ""}}{{with .Headers}}/// - Note: expected HTTP headers (set them in APIClient.headers): {{join . ", "}}
+{{end}}{{swDeprecated .Deprecated ""}}public struct {{.TsName}} {
+    public let client: APIClient
+
+    public init(client: APIClient) {
+        self.client = client
+    }
+{{range .Methods}}
+{{swDocs .Docs "    "}}{{swDeprecated .Deprecated "    "}}    public func {{swName .Name}}({{swMethodParams .}}) {{if not .IsFileUpload}}async throws {{end}}-> {{swReturnType .}} {
+        {{swMethodBody $service .}}
+    }
+{{end}}}
+`
+
+// endregion
@@ -43,7 +43,7 @@ type TsProcessor struct {
 }
 
 // NewTsProcessor - Factory method
-func NewTsProcessor(model *model.MetaModel, output string) Processor {
+func NewTsProcessor(model *model.MetaModel, output string) *TsProcessor {
 	return &TsProcessor{BaseProcessor{
 		Output: output,
 		Model:  model,
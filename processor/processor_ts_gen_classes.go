@@ -129,10 +129,12 @@ var classTsTemplate = `
 {{. | addImports}}
 
 {{range .Docs}}
-// {{.}}{{end}}
+// {{.}}{{end}}{{with .Deprecated}}
+/** @deprecated {{.}} */{{end}}
 export class {{.Name}}{{. | genericsParam }}{{template "extend" .}} {
 {{range .Fields}}
-	// {{range .Docs}}{{.}} {{end}}
+	// {{range .Docs}}{{.}} {{end}}{{with .Deprecated}}
+	/** @deprecated {{.}} */{{end}}
 	public {{.Json}}: {{.TsType }}{{ if .IsArray }}[]{{ end }};
 {{end}}
 {{ if not .IsExtend }}{{. | addConstructor }}{{end}}
@@ -94,10 +94,12 @@ var enumTsTemplate = `
 import { Tuple } from '.';
 
 {{range .Docs}}
-// {{.}}{{end}}
+// {{.}}{{end}}{{with .Deprecated}}
+/** @deprecated {{.}} */{{end}}
 export enum {{.Name}} {
 {{range .Values}}
-    // {{range .Docs}}{{.}} {{end}}
+    // {{range .Docs}}{{.}} {{end}}{{with .Deprecated}}
+    /** @deprecated {{.}} */{{end}}
     {{.Name}} = {{.Value}},
 {{end}}
 }
@@ -120,10 +122,12 @@ export function Map{{.Name}}s() : Map<{{.Name}}, string> {
 `
 
 var enumFlagsTsTemplate = `
-// {{range .Docs}} {{.}} {{end}}
+// {{range .Docs}} {{.}} {{end}}{{with .Deprecated}}
+/** @deprecated {{.}} */{{end}}
 export enum {{.Name}} {
  {{range .Values}}
-    // {{range .Docs}}{{.}} {{end}}
+    // {{range .Docs}}{{.}} {{end}}{{with .Deprecated}}
+    /** @deprecated {{.}} */{{end}}
     {{.Name}} = {{.Shifter}} << {{.Value}},
  {{end}}
 }
@@ -253,7 +253,8 @@ import { APP_CONFIG, AppConfig } from '../../config';
 {{. | addServiceImports}}
 
 {{range .Docs}}
-// {{.}} {{end}}
+// {{.}} {{end}}{{with .Deprecated}}
+/** @deprecated {{.}} */{{end}}
 @Injectable({
   providedIn: 'root'
 })
@@ -269,7 +270,8 @@ export class {{.TsName}} {
 
 {{range .Methods}}
   /**{{range .Docs}}
-   * {{.}}{{end}}
+   * {{.}}{{end}}{{with .Deprecated}}
+   * @deprecated {{.}}{{end}}
    */
   {{.Name | toCamelCase}}({{. | handleMethodParams}}) {
     {{. | methodContent}}
@@ -0,0 +1,184 @@
+// API explorer generated by yaaf-code-gen: sends the "try it" forms of the resource pages as real HTTP requests
+(function () {
+  'use strict';
+
+  var settingsKey = 'yaaf-api-explorer';
+
+  // current base URL and auth token (shared by the forms of the page)
+  var settings = {baseUrl: '', token: ''};
+
+  // storage access fails when it is not available (e.g. private mode), the settings are kept for this page only
+  function getItem(storage, key) {
+    try {
+      return window[storage].getItem(key);
+    } catch (e) {
+      return null;
+    }
+  }
+
+  function setItem(storage, key, value) {
+    try {
+      if (value === null) {
+        window[storage].removeItem(key);
+      } else {
+        window[storage].setItem(key, value);
+      }
+    } catch (e) {
+      // storage is not available
+    }
+  }
+
+  // the edited base URL is saved per generated default, so regenerated documentation with a new default base URL
+  // does not keep using the old one
+  function baseUrlKey(defaultUrl) {
+    return settingsKey + ':baseUrl:' + defaultUrl;
+  }
+
+  function inputs(form, location) {
+    return Array.prototype.slice.call(form.querySelectorAll('[data-in="' + location + '"]'));
+  }
+
+  // build the request from the form inputs, returns null and marks the input when the value is not valid
+  function buildRequest(form) {
+    var path = form.getAttribute('data-path');
+    var request = {method: form.getAttribute('data-method'), headers: {}, body: null, bodyText: null};
+
+    inputs(form, 'path').forEach(function (input) {
+      path = path.split('{' + input.name + '}').join(encodeURIComponent(input.value));
+    });
+
+    var query = [];
+    inputs(form, 'query').forEach(function (input) {
+      if (input.value.length > 0) {
+        query.push(encodeURIComponent(input.name) + '=' + encodeURIComponent(input.value));
+      }
+    });
+
+    inputs(form, 'header').forEach(function (input) {
+      if (input.value.length > 0) {
+        request.headers[input.name] = input.value;
+      }
+    });
+    if (settings.token) {
+      request.headers['Authorization'] = settings.token.indexOf(' ') > 0 ? settings.token : 'Bearer ' + settings.token;
+    }
+
+    // body is not sent with GET and DELETE requests (fetch rejects GET request with body)
+    var valid = true;
+    var hasBody = request.method !== 'GET' && request.method !== 'DELETE';
+    inputs(form, 'body').forEach(function (input) {
+      input.classList.remove('invalid');
+      if (!hasBody || input.value.trim().length === 0) {
+        return;
+      }
+      try {
+        JSON.parse(input.value);
+      } catch (e) {
+        input.classList.add('invalid');
+        valid = false;
+        return;
+      }
+      request.headers['Content-Type'] = 'application/json';
+      request.body = input.value;
+      request.bodyText = input.value;
+    });
+
+    inputs(form, 'file').forEach(function (input) {
+      if (input.files.length > 0) {
+        request.body = new FormData();
+        request.body.append('fileKey', input.files[0], input.files[0].name);
+        request.bodyText = '@' + input.files[0].name;
+      }
+    });
+
+    request.url = (settings.baseUrl || '').replace(/\/+$/, '') + path + (query.length > 0 ? '?' + query.join('&') : '');
+    return valid ? request : null;
+  }
+
+  // build the equivalent curl command (to share the request)
+  function curl(request) {
+    var quote = function (s) {
+      return "'" + String(s).split("'").join("'\\''") + "'";
+    };
+    var parts = ['curl', '-X', request.method, quote(request.url)];
+    Object.keys(request.headers).forEach(function (name) {
+      if (!(request.body instanceof FormData && name === 'Content-Type')) {
+        parts.push('-H', quote(name + ': ' + request.headers[name]));
+      }
+    });
+    if (request.body instanceof FormData) {
+      parts.push('-F', quote('fileKey=' + request.bodyText));
+    } else if (request.bodyText !== null) {
+      parts.push('-d', quote(request.bodyText));
+    }
+    return parts.join(' ');
+  }
+
+  function showResponse(form, status, text) {
+    var panel = form.querySelector('.response');
+    panel.hidden = false;
+    panel.querySelector('.response-status').textContent = status;
+    panel.querySelector('.response-body').textContent = text;
+  }
+
+  function send(form) {
+    var request = buildRequest(form);
+    if (request === null) {
+      showResponse(form, 'Invalid JSON body', '');
+      return;
+    }
+    form.querySelector('.request-curl').textContent = curl(request);
+
+    var started = Date.now();
+    showResponse(form, 'Sending...', '');
+    fetch(request.url, {method: request.method, headers: request.headers, body: request.body})
+      .then(function (response) {
+        return response.text().then(function (text) {
+          var type = response.headers.get('Content-Type') || '';
+          if (type.indexOf('json') >= 0) {
+            try {
+              text = JSON.stringify(JSON.parse(text), null, 2);
+            } catch (e) {
+              // not a valid JSON, show the raw text
+            }
+          }
+          showResponse(form, response.status + ' ' + response.statusText + ' (' + (Date.now() - started) + ' ms)', text);
+        });
+      })
+      .catch(function (error) {
+        showResponse(form, 'Request failed (check the base URL and that the server allows CORS requests from this page)', String(error));
+      });
+  }
+
+  document.addEventListener('DOMContentLoaded', function () {
+    var baseUrl = document.getElementById('explorer-base-url');
+    var token = document.getElementById('explorer-token');
+    if (baseUrl !== null) {
+      // the base URL is saved only when the user changes the generated default, the auth token is kept for the
+      // browser tab session only (it is never persisted)
+      var defaultUrl = baseUrl.value;
+      baseUrl.value = getItem('localStorage', baseUrlKey(defaultUrl)) || defaultUrl;
+      token.value = getItem('sessionStorage', settingsKey + ':token') || '';
+      var update = function () {
+        settings.baseUrl = baseUrl.value;
+        settings.token = token.value;
+      };
+      update();
+      baseUrl.addEventListener('change', function () {
+        update();
+        setItem('localStorage', baseUrlKey(defaultUrl), baseUrl.value === defaultUrl ? null : baseUrl.value);
+      });
+      token.addEventListener('change', function () {
+        update();
+        setItem('sessionStorage', settingsKey + ':token', token.value.length > 0 ? token.value : null);
+      });
+    }
+
+    Array.prototype.slice.call(document.querySelectorAll('form.try-it')).forEach(function (form) {
+      form.addEventListener('submit', function (event) {
+        event.preventDefault();
+        send(form);
+      });
+    });
+  });
+})();
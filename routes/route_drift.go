@@ -0,0 +1,177 @@
+package routes
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+// region Drift structure ----------------------------------------------------------------------------------------------
+
+// Drift kinds
+const (
+	DriftUnregistered = "unregistered" // The method is annotated but its route is not registered
+	DriftUnannotated  = "unannotated"  // The route is registered but no method is annotated with it
+	DriftMismatch     = "mismatch"     // The route is registered with different verb or path than the annotation
+)
+
+// Drift describes single difference between the annotated routes and the registered routes
+type Drift struct {
+	Kind     string `json:"kind"`     // Kind of the drift: unregistered | unannotated | mismatch
+	Name     string `json:"name"`     // Full name of the annotated method (e.g. UsersService.Get), empty for unannotated routes
+	Route    string `json:"route"`    // The route (annotated route, or registered route of unannotated routes)
+	Location string `json:"location"` // Source location of the registered route (empty for unregistered routes)
+	Message  string `json:"message"`  // Description of the drift
+}
+
+func (d *Drift) String() string {
+	name := d.Name
+	if len(name) == 0 {
+		name = d.Route
+	}
+	if len(d.Location) > 0 {
+		return fmt.Sprintf("[%s] %s: %s (%s)", d.Kind, name, d.Message, d.Location)
+	}
+	return fmt.Sprintf("[%s] %s: %s", d.Kind, name, d.Message)
+}
+
+// endregion
+
+// region Compare ------------------------------------------------------------------------------------------------------
+
+// routeParam matches path parameter of any router syntax: {id}, {id:[0-9]+}, {path...}, :id and *path
+var routeParam = regexp.MustCompile(`^(\{[^}]*}|:.+|\*.*)$`)
+
+// annotatedRoute is route of annotated service method
+type annotatedRoute struct {
+	name   string
+	method string
+	path   string
+}
+
+// Compare the annotated routes of the model services with the registered routes, the prefix (e.g. /api/v1) is removed
+// from the registered paths which start with it. Path parameters are compared by position (the names and syntax may
+// differ) and trailing slashes are ignored, routes of the same path with other verb or other letter case are reported
+// as mismatch.
+func Compare(mm *model.MetaModel, registered []*Route, prefix string) []*Drift {
+	prefix = strings.TrimSuffix(prefix, "/")
+	annotated := make([]*annotatedRoute, 0)
+	for _, si := range mm.ListServices() {
+		for _, mi := range si.Methods {
+			if mi.IsSocketMessage || len(mi.Method) == 0 {
+				continue
+			}
+			annotated = append(annotated, &annotatedRoute{
+				name:   fmt.Sprintf("%s.%s", si.Name, mi.Name),
+				method: strings.ToUpper(mi.Method),
+				path:   si.MethodPath(mi),
+			})
+		}
+	}
+
+	paths := make([]string, len(registered))
+	for i, r := range registered {
+		paths[i] = r.Path
+		if len(prefix) > 0 && (r.Path == prefix || strings.HasPrefix(r.Path, prefix+"/")) {
+			paths[i] = strings.TrimPrefix(r.Path, prefix)
+		}
+	}
+
+	// Exact matches first, so the loose match does not take a route of other annotation
+	used := make([]bool, len(registered))
+	exact := make([]int, len(annotated))
+	for k, a := range annotated {
+		exact[k] = -1
+		for i, r := range registered {
+			verbMatch := len(r.Method) == 0 || r.Method == a.method
+			if normalizePath(paths[i]) == normalizePath(a.path) && verbMatch {
+				exact[k] = i
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	list := make([]*Drift, 0)
+	for k, a := range annotated {
+		if exact[k] >= 0 {
+			continue
+		}
+		route := fmt.Sprintf("%s %s", a.method, a.path)
+		loose := -1
+		for i := range registered {
+			if !used[i] && looseKey(paths[i]) == looseKey(a.path) {
+				loose = i
+				break
+			}
+		}
+		if loose >= 0 {
+			used[loose] = true
+			list = append(list, &Drift{
+				Kind: DriftMismatch, Name: a.name, Route: route, Location: registered[loose].Location(),
+				Message: fmt.Sprintf("annotated %s but registered %s", route, registered[loose]),
+			})
+		} else {
+			list = append(list, &Drift{
+				Kind: DriftUnregistered, Name: a.name, Route: route,
+				Message: fmt.Sprintf("%s is annotated but not registered", route),
+			})
+		}
+	}
+
+	for i, r := range registered {
+		if used[i] {
+			continue
+		}
+		matched := false
+		for j := 0; j < i && !matched; j++ {
+			matched = used[j] && registered[j].Method == r.Method && normalizePath(paths[j]) == normalizePath(paths[i])
+		}
+		if matched {
+			continue // same route registered twice (e.g. in different routers)
+		}
+		list = append(list, &Drift{
+			Kind: DriftUnannotated, Route: r.String(), Location: r.Location(),
+			Message: fmt.Sprintf("%s is registered but not annotated", r),
+		})
+	}
+
+	order := map[string]int{DriftMismatch: 0, DriftUnregistered: 1, DriftUnannotated: 2}
+	sort.SliceStable(list, func(i, j int) bool {
+		if order[list[i].Kind] != order[list[j].Kind] {
+			return order[list[i].Kind] < order[list[j].Kind]
+		}
+		return list[i].Route < list[j].Route
+	})
+	return list
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// Normalize path for comparison: parameters are replaced by {} and the trailing slash and {$} suffix are removed
+func normalizePath(path string) string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case len(segment) == 0 || segment == "{$}":
+			continue
+		case routeParam.MatchString(segment):
+			segments = append(segments, "{}")
+		default:
+			segments = append(segments, segment)
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// Get the path key ignoring the letter case (used to find mismatches)
+func looseKey(path string) string {
+	return strings.ToLower(normalizePath(path))
+}
+
+// endregion
@@ -0,0 +1,278 @@
+// Package routes finds the route registrations of hand-written routers in Go source code and compares them with the
+// @Http annotations of the model services, so drift between the annotations and the real routes is detected
+package routes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// region Route structure ----------------------------------------------------------------------------------------------
+
+// Route is route registration found in the source code
+type Route struct {
+	Method string `json:"method"` // HTTP verb (empty if the route matches any verb)
+	Path   string `json:"path"`   // Route path including the group prefixes (e.g. /users/{id} or /users/:id)
+	File   string `json:"file"`   // Source file of the registration
+	Line   int    `json:"line"`   // Source line of the registration
+}
+
+func (r *Route) String() string {
+	method := r.Method
+	if len(method) == 0 {
+		method = "*"
+	}
+	return fmt.Sprintf("%s %s", method, r.Path)
+}
+
+// Location gets the source location of the registration (file:line)
+func (r *Route) Location() string {
+	return fmt.Sprintf("%s:%d", r.File, r.Line)
+}
+
+// endregion
+
+// region Scanner ------------------------------------------------------------------------------------------------------
+
+// httpVerbs are the HTTP verbs, also used as router method names (gin and echo use upper case, chi and fiber use title
+// case)
+var httpVerbs = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}
+
+// ScanFolder scans the Go files of the folder and its sub folders (except vendor folders and test files) for route
+// registrations
+func ScanFolder(folder string) ([]*Route, error) {
+	list := make([]*Route, 0)
+	err := filepath.Walk(folder, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == "vendor" || strings.HasPrefix(info.Name(), ".")) && filePath != folder {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(filePath, ".go") || strings.HasSuffix(filePath, "_test.go") {
+			return nil
+		}
+		routes, er := ScanFile(filePath)
+		if er != nil {
+			return er
+		}
+		list = append(list, routes...)
+		return nil
+	})
+	return list, err
+}
+
+// ScanFile scans Go file for route registrations:
+//   - net/http: mux.HandleFunc("GET /users/{id}", h), mux.Handle(pattern, h)
+//   - gorilla/mux: r.HandleFunc("/users/{id}", h).Methods("GET"), r.PathPrefix("/api").Subrouter()
+//   - chi: r.Get("/users/{id}", h), r.Method("GET", path, h), r.Route("/api", func(r chi.Router) {...})
+//   - gin, echo and fiber: r.GET("/users/:id", h), r.Handle("GET", path, h), r.Add("GET", path, h), r.Group("/api")
+//
+// Only literal paths are found (string literals and their concatenation)
+func ScanFile(filePath string) ([]*Route, error) {
+	fSet := token.NewFileSet()
+	file, err := parser.ParseFile(fSet, filePath, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := &scanner{fSet: fSet, fileName: filePath, verbs: make(map[*ast.CallExpr][]string), routes: make([]*Route, 0)}
+	s.walk(file, make(map[string]string))
+
+	sort.SliceStable(s.routes, func(i, j int) bool { return s.routes[i].Line < s.routes[j].Line })
+	return s.routes, nil
+}
+
+// scanner collects the route registrations of single file
+type scanner struct {
+	fSet     *token.FileSet
+	fileName string
+	verbs    map[*ast.CallExpr][]string // Verbs of gorilla routes set by chained Methods() call
+	routes   []*Route
+}
+
+// Walk the node, prefixes map router variables to their path prefix (e.g. api := r.Group("/api"))
+func (s *scanner) walk(node ast.Node, prefixes map[string]string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && i < len(n.Rhs) {
+					if prefix, ok := s.prefix(n.Rhs[i], prefixes); ok {
+						prefixes[ident.Name] = prefix
+					}
+				}
+			}
+		case *ast.CallExpr:
+			return s.call(n, prefixes)
+		}
+		return true
+	})
+}
+
+// Process call expression, returns false when the children were already walked
+func (s *scanner) call(call *ast.CallExpr, prefixes map[string]string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return true
+	}
+	name := sel.Sel.Name
+	if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "http" && name != "HandleFunc" && name != "Handle" {
+		return true // HTTP client calls (http.Get, http.Post ...)
+	}
+
+	switch {
+	case name == "Methods":
+		// gorilla: the verbs of the route registered by the chained call
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			for _, arg := range call.Args {
+				if verb, ok := stringValue(arg); ok {
+					s.verbs[inner] = append(s.verbs[inner], strings.ToUpper(verb))
+				}
+			}
+		}
+	case name == "Route" && len(call.Args) == 2:
+		// chi: sub router function with the path prefix
+		fn, isFunc := call.Args[1].(*ast.FuncLit)
+		path, isPath := stringValue(call.Args[0])
+		if isFunc && isPath && len(fn.Type.Params.List) == 1 && len(fn.Type.Params.List[0].Names) == 1 {
+			prefix, _ := s.prefix(sel.X, prefixes)
+			scope := make(map[string]string)
+			for k, v := range prefixes {
+				scope[k] = v
+			}
+			scope[fn.Type.Params.List[0].Names[0].Name] = prefix + path
+			s.walk(fn.Body, scope)
+			return false
+		}
+	case (name == "HandleFunc" || name == "Handle") && len(call.Args) >= 2:
+		if verb, ok := stringValue(call.Args[0]); ok && isVerb(verb) && len(call.Args) >= 3 {
+			// gin: r.Handle("GET", path, h)
+			s.register(call, sel.X, verb, call.Args[1], prefixes)
+		} else if pattern, ok := stringValue(call.Args[0]); ok {
+			// net/http pattern "[METHOD ][HOST]/path" or gorilla path (verbs set by Methods)
+			verb, path := "", pattern
+			if before, after, found := strings.Cut(pattern, " "); found {
+				verb, path = before, strings.TrimSpace(after)
+			}
+			if i := strings.Index(path, "/"); i > 0 {
+				path = path[i:] // remove the host
+			}
+			verbs := s.verbs[call]
+			if len(verbs) == 0 {
+				verbs = []string{verb}
+			}
+			for _, v := range verbs {
+				s.add(call, sel.X, v, path, prefixes)
+			}
+		}
+	case (name == "Method" || name == "MethodFunc" || name == "Add") && len(call.Args) >= 3:
+		// chi: r.Method("GET", path, h), echo: e.Add("GET", path, h)
+		if verb, ok := stringValue(call.Args[0]); ok && isVerb(verb) {
+			s.register(call, sel.X, verb, call.Args[1], prefixes)
+		}
+	case isVerb(name) && len(call.Args) >= 2:
+		// chi and fiber: r.Get(path, h), gin and echo: r.GET(path, h)
+		s.register(call, sel.X, name, call.Args[0], prefixes)
+	}
+	return true
+}
+
+// Register route of literal path
+func (s *scanner) register(call *ast.CallExpr, router ast.Expr, verb string, pathArg ast.Expr, prefixes map[string]string) {
+	if path, ok := stringValue(pathArg); ok {
+		s.add(call, router, verb, path, prefixes)
+	}
+}
+
+// Add route, the path is prefixed by the router group prefix
+func (s *scanner) add(call *ast.CallExpr, router ast.Expr, verb string, path string, prefixes map[string]string) {
+	if !strings.HasPrefix(path, "/") && len(path) > 0 {
+		return
+	}
+	prefix, _ := s.prefix(router, prefixes)
+	s.routes = append(s.routes, &Route{
+		Method: strings.ToUpper(verb),
+		Path:   prefix + path,
+		File:   s.fileName,
+		Line:   s.fSet.Position(call.Pos()).Line,
+	})
+}
+
+// Get the path prefix of router expression: group variable, r.Group("/api"), r.PathPrefix("/api").Subrouter() or
+// r.Route("/api") (ok is false if the expression is not a router group)
+func (s *scanner) prefix(expr ast.Expr, prefixes map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		prefix, ok := prefixes[e.Name]
+		return prefix, ok
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		switch sel.Sel.Name {
+		case "Group", "PathPrefix", "Route":
+			if len(e.Args) == 0 {
+				return "", false
+			}
+			path, ok := stringValue(e.Args[0])
+			if !ok {
+				return "", false
+			}
+			parent, _ := s.prefix(sel.X, prefixes)
+			return parent + path, true
+		case "Subrouter":
+			return s.prefix(sel.X, prefixes)
+		}
+	}
+	return "", false
+}
+
+// endregion
+
+// region Helpers ------------------------------------------------------------------------------------------------------
+
+// Get the value of string literal or concatenation of string literals
+func stringValue(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := stringValue(e.X)
+		if !ok {
+			return "", false
+		}
+		right, ok := stringValue(e.Y)
+		return left + right, ok
+	case *ast.ParenExpr:
+		return stringValue(e.X)
+	}
+	return "", false
+}
+
+// Check if the name is HTTP verb (case insensitive, GET or Get)
+func isVerb(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, verb := range httpVerbs {
+		if upper == verb && (name == upper || name == verb[:1]+strings.ToLower(verb[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// endregion
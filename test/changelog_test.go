@@ -0,0 +1,50 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestChangelogProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	prev := sampleModel()
+	curr := sampleModel()
+	curr.GetClass("User").AddField("Phone", "string", "User phone")
+	svc := curr.GetService("UsersService")
+	svc.Methods[2].SetAction("PUT /")
+	svc.Methods = svc.Methods[:4]
+
+	orders := model.NewServiceInfo("OrdersService", "Orders management")
+	orders.Path = "/orders"
+	orders.Group = "Orders"
+	list := model.NewMethodInfo("List")
+	list.Docs = append(list.Docs, "List orders")
+	list.SetAction("GET /")
+	orders.Methods = append(orders.Methods, list)
+	curr.AddServiceInfo(orders)
+
+	err := processor.NewChangelogProcessor(curr, outDir, prev).WithTitle("API v2").Start()
+	require.Nil(t, err)
+
+	content := readFile(t, path.Join(outDir, "changelog.md"))
+	require.Contains(t, content, "# API v2\n")
+	require.Contains(t, content, "**2** added, **1** changed, **1** removed (**2 breaking**)")
+	require.Contains(t, content, "### Orders\n\n#### Added\n- `OrdersService /orders` - Orders management\n  - GET /orders - List orders\n")
+	require.Contains(t, content, "- **Breaking:** `PUT /users` - Create new user\n  - HTTP verb changed from POST to PUT\n")
+	require.Contains(t, content, "#### Removed\n- **Breaking:** `POST /users/{id}/avatar` - Upload user avatar\n")
+	require.Contains(t, content, "- `User.phone: string` - User phone\n")
+
+	content = readFile(t, path.Join(outDir, "changelog.html"))
+	require.Contains(t, content, `<span class="badge">breaking</span> <code>PUT /users</code>`)
+
+	// No changes
+	err = processor.NewChangelogProcessor(prev, outDir, sampleModel()).Start()
+	require.Nil(t, err)
+	require.Contains(t, readFile(t, path.Join(outDir, "changelog.md")), "No API changes.")
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestCSharpProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewCSharpProcessor(sampleModel(), outDir, "Acme.Api").Start()
+	require.Nil(t, err)
+
+	project := readFile(t, path.Join(outDir, "Acme.Api.csproj"))
+	require.Contains(t, project, "<TargetFramework>net8.0</TargetFramework>")
+	require.Contains(t, project, "<RootNamespace>Acme.Api</RootNamespace>")
+	require.FileExists(t, path.Join(outDir, "ApiClientBase.cs"))
+
+	enum := readFile(t, path.Join(outDir, "Models", "UserStatusCode.cs"))
+	require.Contains(t, enum, "namespace Acme.Api.Models;")
+	require.Contains(t, enum, "public enum UserStatusCode\n{\n    /// <summary>UNDEFINED status</summary>\n    Undefined = 0,")
+
+	user := readFile(t, path.Join(outDir, "Models", "User.cs"))
+	require.Contains(t, user, "public record User : BaseEntity\n{")
+	require.Contains(t, user, "    [JsonPropertyName(\"status\")]\n    public UserStatusCode? Status { get; init; }")
+	require.Contains(t, user, "    [JsonPropertyName(\"roles\")]\n    public List<string>? Roles { get; init; }")
+	require.Contains(t, user, "    [JsonPropertyName(\"props\")]\n    public JsonObject? Props { get; init; }")
+
+	service := readFile(t, path.Join(outDir, "Services", "UsersService.cs"))
+	require.Contains(t, service, "/// <remarks>Expected HTTP headers: X-API-KEY</remarks>\npublic class UsersService : ApiClientBase")
+	require.Contains(t, service, "    public UsersService(HttpClient client, string apiUrl) : base(client, apiUrl, \"/users\")")
+	require.Contains(t, service, "    public async Task<EntityResponse<User>> GetAsync(string id, CancellationToken cancellationToken = default)")
+	require.Contains(t, service, "SendAsync(HttpMethod.Get, BuildUri($\"/{Escape(id)}\"), null, cancellationToken)")
+	require.Contains(t, service, "    public async Task<JsonElement> FindAsync(string? search = null, IEnumerable<UserStatusCode>? status = null, int? page = null, CancellationToken cancellationToken = default)")
+	require.Contains(t, service, "BuildUri(\"\", (\"search\", Format(search)), (\"status\", Format(status)), (\"page\", Format(page)))")
+	require.Contains(t, service, "SendAsync(HttpMethod.Post, BuildUri(\"\"), JsonBody(user), cancellationToken)")
+	require.Contains(t, service, "    public async Task<JsonElement> UploadAsync(byte[] file, string id, CancellationToken cancellationToken = default)")
+
+	// The generated project compiles (when the .NET SDK is installed)
+	dotnet, err := exec.LookPath("dotnet")
+	if err != nil {
+		t.Skip("dotnet is not installed, skipping the compile check")
+	}
+	cmd := exec.Command(dotnet, "build", "-nologo")
+	cmd.Dir = outDir
+	output, err := cmd.CombinedOutput()
+	require.Nil(t, err, string(output))
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestDartProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewDartProcessor(sampleModel(), outDir, "acme_api").Start()
+	require.Nil(t, err)
+	lib := path.Join(outDir, "lib")
+
+	require.Contains(t, readFile(t, path.Join(outDir, "pubspec.yaml")), "name: acme_api\n")
+	library := readFile(t, path.Join(lib, "acme_api.dart"))
+	require.Contains(t, library, "export 'models/models.dart';\nexport 'services/services.dart';")
+	require.FileExists(t, path.Join(lib, "services", "api_service.dart"))
+
+	enum := readFile(t, path.Join(lib, "models", "user_status_code.dart"))
+	require.Contains(t, enum, "enum UserStatusCode {")
+	require.Contains(t, enum, "  /// BLOCKED status\n  blocked(2);\n")
+	require.Contains(t, enum, "  static UserStatusCode fromValue(int value) => values.firstWhere((e) => e.value == value);")
+
+	user := readFile(t, path.Join(lib, "models", "user.dart"))
+	require.Contains(t, user, "/// User entity\nclass User {")
+	require.Contains(t, user, "  final List<String>? roles;\n")
+	require.Contains(t, user, "  const User({this.id, this.createdOn, this.name, this.email, this.status, this.roles, this.props});")
+	require.Contains(t, user, "        createdOn: json['createdOn'] == null ? null : (json['createdOn'] as num).toInt(),")
+	require.Contains(t, user, "        status: json['status'] == null ? null : UserStatusCode.fromValue((json['status'] as num).toInt()),")
+	require.Contains(t, user, "        'status': status == null ? null : status!.value,")
+
+	service := readFile(t, path.Join(lib, "services", "users_service.dart"))
+	require.Contains(t, service, "/// Expected HTTP headers: X-API-KEY\nclass UsersService extends ApiService {")
+	require.Contains(t, service, "      : super(apiUrl, '/users', client: client, headers: headers);")
+	require.Contains(t, service, "  Future<EntityResponse<User>> get(String id) async {\n    final response = await send('GET', buildUri('/${Uri.encodeComponent(id.toString())}', {}));")
+	require.Contains(t, service, "(e) => User.fromJson(e as Map<String, dynamic>));")
+	require.Contains(t, service, "  Future<dynamic> find({String? search, List<UserStatusCode>? status, int? page}) async {")
+	require.Contains(t, service, "'status': status == null ? null : status.map((e) => e.value).join(',')")
+	require.Contains(t, service, "    final response = await send('POST', buildUri('', {}), user.toJson());")
+	require.Contains(t, service, "    final response = await sendFile('POST', buildUri('/${Uri.encodeComponent(id.toString())}/avatar', {}), file);")
+}
@@ -1,8 +1,13 @@
 package test
 
 import (
+	"fmt"
 	"os"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
 )
 
 func skipCI(t *testing.T) {
@@ -10,3 +15,111 @@ func skipCI(t *testing.T) {
 		t.Skip("Skipping testing in CI environment")
 	}
 }
+
+// Build sample meta model for processors tests (no source files required)
+func sampleModel() *model.MetaModel {
+	mm := model.NewMetaModel()
+
+	status := model.NewEnumInfo("UserStatusCode", "User status code")
+	for i, name := range []string{"UNDEFINED", "ACTIVE", "BLOCKED"} {
+		ev := model.NewEnumValueInfo(name, fmt.Sprintf("%s status", name))
+		ev.Value = i
+		status.AddValue(ev)
+	}
+	mm.AddEnumInfo(status)
+
+	base := model.NewClassInfo("BaseEntity", "Base class for all entities")
+	base.AddField("Id", "string", "Unique object Id")
+	base.AddField("CreatedOn", "Timestamp", "When the object was created")
+	base.GetField("CreatedOn").Format = "datetime"
+	mm.AddClassInfo(base)
+
+	user := model.NewClassInfo("User", "User entity")
+	user.TableName = "user"
+	user.IsExtend = true
+	user.BaseClass = "BaseEntity"
+	user.AddField("Name", "string", "User name")
+	user.GetField("Name").IsNotNull = true
+	user.AddField("Email", "string", "User email")
+	user.GetField("Email").Format = "email"
+	user.GetField("Email").Uniques = []string{""}
+	user.AddField("Status", "UserStatusCode", "User status")
+	user.GetField("Status").Indexes = []string{""}
+	user.AddField("Roles", "string", "User roles")
+	user.GetField("Roles").IsArray = true
+	user.AddField("Props", "Json", "Custom properties")
+	mm.AddClassInfo(user)
+
+	resp := model.NewClassInfo("EntityResponse", "Single entity response")
+	resp.IsGeneric = true
+	resp.GenericTypes = append(resp.GenericTypes, model.StringKeyValue{Key: "T", Value: "any"})
+	resp.AddField("Code", "int", "Error code (0 for success)")
+	resp.AddField("Data", "T", "Entity")
+	mm.AddClassInfo(resp)
+
+	page := model.NewClassInfo("UsersPage", "Page of users")
+	page.AddField("Current", "EntityResponse[User]", "Current user")
+	page.AddField("Total", "int64", "Total number of users")
+	mm.AddClassInfo(page)
+
+	svc := model.NewServiceInfo("UsersService", "Users management")
+	svc.TsName = "UsersService"
+	svc.Path = "/users"
+	svc.Group = "Users"
+	svc.AddHeader("X-API-KEY")
+
+	get := model.NewMethodInfo("Get")
+	get.Docs = append(get.Docs, "Get single user by id")
+	get.SetAction("GET /{id}")
+	get.AddPathParam("id | string | The user id")
+	get.Return = model.NewClassInfo("EntityResponse<User>")
+	get.SetReturnType("EntityResponse<User>")
+	svc.Methods = append(svc.Methods, get)
+
+	find := model.NewMethodInfo("Find")
+	find.Docs = append(find.Docs, "Find users by query")
+	find.SetAction("GET /")
+	find.AddQueryParam("search | string | Search term")
+	find.AddQueryParam("status | []UserStatusCode | Filter by status")
+	find.AddQueryParam("page | int | Page number")
+	find.Return = model.NewClassInfo("EntitiesResponse<User>")
+	find.SetReturnType("EntitiesResponse<User>")
+	svc.Methods = append(svc.Methods, find)
+
+	create := model.NewMethodInfo("Create")
+	create.Docs = append(create.Docs, "Create new user")
+	create.SetAction("POST /")
+	create.AddBodyParam("user | User | The user to create")
+	create.Return = model.NewClassInfo("EntityResponse<User>")
+	create.SetReturnType("EntityResponse<User>")
+	svc.Methods = append(svc.Methods, create)
+
+	remove := model.NewMethodInfo("Delete")
+	remove.Docs = append(remove.Docs, "Delete user")
+	remove.SetAction("DELETE /{id}")
+	remove.AddPathParam("id | string | The user id")
+	remove.Return = model.NewClassInfo("ActionResponse")
+	remove.SetReturnType("ActionResponse")
+	svc.Methods = append(svc.Methods, remove)
+
+	upload := model.NewMethodInfo("Upload")
+	upload.Docs = append(upload.Docs, "Upload user avatar")
+	upload.SetAction("POST /{id}/avatar")
+	upload.AddPathParam("id | string | The user id")
+	upload.AddFileParam("file | bytes | The avatar image")
+	upload.Return = model.NewClassInfo("ActionResponse")
+	upload.SetReturnType("ActionResponse")
+	svc.Methods = append(svc.Methods, upload)
+
+	mm.AddServiceInfo(svc)
+
+	mm.FillDependencies()
+	return mm
+}
+
+// Read generated file content
+func readFile(t *testing.T, fileName string) string {
+	bytes, err := os.ReadFile(fileName)
+	require.Nil(t, err)
+	return string(bytes)
+}
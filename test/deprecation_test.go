@@ -0,0 +1,167 @@
+package test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	generator "github.com/go-yaaf/yaaf-code-gen"
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+var deprecatedSource = `package api
+
+// Account status
+// @Enum
+type AccountStatusCode int
+
+// @EnumValuesFor: AccountStatusCode
+type accountStatusCodes struct {
+	// Active account
+	ACTIVE int ` + "`value:\"1\"`" + `
+	// Suspended account
+	// @Deprecated: merged into blocked | v2.3 | BLOCKED
+	SUSPENDED int ` + "`value:\"2\"`" + `
+	// Blocked account
+	BLOCKED int ` + "`value:\"3\"`" + `
+}
+
+// Legacy account profile
+// @Data
+// @Deprecated: use Account | v2.0 | Account
+type Profile struct {
+	// Profile name
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// Account
+// @Data
+type Account struct {
+	// Account id
+	Id string ` + "`json:\"id\"`" + `
+	// Account phone
+	// @Deprecated: use contacts | v2.1
+	Phone string ` + "`json:\"phone\"`" + `
+	// Account status
+	Status AccountStatusCode ` + "`json:\"status\"`" + `
+}
+
+// Accounts management
+// @Service: AccountsService
+// @Path: /accounts
+type AccountsService struct {
+}
+
+// Get account by id
+// @Http: GET /{id}
+// @PathParam: id | string | The account id
+// @Return: Account
+func (s *AccountsService) get() {}
+
+// Find account by phone
+// @Http: GET /find
+// @QueryParam: phone | string | The account phone
+// @Deprecated
+// @Return: Account
+func (s *AccountsService) find() {}
+`
+
+func deprecatedModel(t *testing.T) *model.MetaModel {
+	srcDir := t.TempDir()
+	err := os.WriteFile(path.Join(srcDir, "api.go"), []byte(deprecatedSource), 0644)
+	require.Nil(t, err)
+
+	cg := generator.NewCodeGenerator().WithSourceFolder(srcDir, "")
+	require.Nil(t, cg.Parse())
+	return cg.Model
+}
+
+func TestDeprecationParser(t *testing.T) {
+	mm := deprecatedModel(t)
+
+	profile := mm.GetClass("Profile")
+	require.NotNil(t, profile.Deprecated)
+	require.Equal(t, model.DeprecationInfo{Reason: "use Account", Since: "v2.0", Replacement: "Account"}, *profile.Deprecated)
+	require.Equal(t, []string{"Legacy account profile"}, profile.Docs)
+
+	account := mm.GetClass("Account")
+	require.Nil(t, account.Deprecated)
+	require.Nil(t, account.GetField("Id").Deprecated)
+	require.Equal(t, "use contacts; deprecated since v2.1", account.GetField("Phone").Deprecated.String())
+
+	status := mm.GetEnum("AccountStatusCode")
+	require.Nil(t, status.Values[0].Deprecated)
+	require.Equal(t, "merged into blocked; deprecated since v2.3; use BLOCKED instead", status.Values[1].Deprecated.String())
+	require.Equal(t, []string{"Suspended account"}, status.Values[1].Docs)
+
+	service := mm.GetService("AccountsService")
+	require.Nil(t, service.Methods[0].Deprecated)
+	require.Equal(t, "no longer supported", service.Methods[1].Deprecated.String())
+
+	// Deprecation is kept in the saved model
+	fileName := path.Join(t.TempDir(), "model.yaml")
+	require.Nil(t, model.Save(mm, fileName))
+	loaded, err := model.Load(fileName)
+	require.Nil(t, err)
+	require.Equal(t, *profile.Deprecated, *loaded.GetClass("Profile").Deprecated)
+	require.Equal(t, "no longer supported", loaded.GetService("AccountsService").Methods[1].Deprecated.String())
+}
+
+func TestDeprecationOutputs(t *testing.T) {
+	mm := deprecatedModel(t)
+
+	// TypeScript
+	tsDir := t.TempDir()
+	require.Nil(t, processor.NewTsProcessor(mm, tsDir).Start())
+	require.Contains(t, readFile(t, path.Join(tsDir, "model", "Account.ts")), "/** @deprecated use contacts; deprecated since v2.1 */")
+	require.Contains(t, readFile(t, path.Join(tsDir, "model", "Profile.ts")), "/** @deprecated use Account; deprecated since v2.0; use Account instead */")
+
+	// JSON Schema
+	schemaDir := t.TempDir()
+	require.Nil(t, processor.NewJsonSchemaProcessor(mm, schemaDir).Start())
+	require.Contains(t, readFile(t, path.Join(schemaDir, "Profile.schema.json")), `"deprecated": true`)
+
+	// GraphQL
+	gqlDir := t.TempDir()
+	require.Nil(t, processor.NewGraphQLProcessor(mm, gqlDir).Start())
+	schema := readFile(t, path.Join(gqlDir, "schema.graphql"))
+	require.Contains(t, schema, `SUSPENDED @deprecated(reason: "merged into blocked; deprecated since v2.3; use BLOCKED instead")`)
+	require.Contains(t, schema, `phone: String @deprecated(reason: "use contacts; deprecated since v2.1")`)
+
+	// Protocol buffers
+	protoDir := t.TempDir()
+	require.Nil(t, processor.NewProtobufProcessor(mm, protoDir, "api").Start())
+	proto := readFile(t, path.Join(protoDir, "api.proto"))
+	require.Contains(t, proto, "string phone = 2 [deprecated = true];")
+	require.Contains(t, proto, "  option deprecated = true;")
+
+	// C#
+	csDir := t.TempDir()
+	require.Nil(t, processor.NewCSharpProcessor(mm, csDir, "Acme.Api").Start())
+	enum := readFile(t, path.Join(csDir, "Models", "AccountStatusCode.cs"))
+	require.Contains(t, enum, "using System;")
+	require.Contains(t, enum, `    [Obsolete("merged into blocked; deprecated since v2.3; use BLOCKED instead")]`)
+
+	// Python (methods inherit the service deprecation)
+	mm.GetService("AccountsService").Deprecated = &model.DeprecationInfo{Reason: "use the v2 API"}
+	pyDir := t.TempDir()
+	require.Nil(t, processor.NewPythonProcessor(mm, pyDir).Start())
+	services := readFile(t, path.Join(pyDir, "services.py"))
+	require.Contains(t, services, "    def get(self, id: str) -> Account:\n        \"\"\"Get account by id\n        Deprecated: use the v2 API\"\"\"")
+	require.Contains(t, services, "        \"\"\"Find account by phone\n        Deprecated: no longer supported\"\"\"")
+}
+
+func TestDeprecationDiff(t *testing.T) {
+	prev := deprecatedModel(t)
+	curr := deprecatedModel(t)
+	curr.GetClass("Account").GetField("Id").Deprecated = model.NewDeprecationInfo("use key")
+
+	changes := model.Diff(prev, curr)
+	require.Len(t, changes, 1)
+	require.Equal(t, model.ActionDeprecated, changes[0].Action)
+	require.Equal(t, "Account.id", changes[0].Name)
+	require.False(t, changes[0].Breaking)
+}
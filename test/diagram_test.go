@@ -0,0 +1,92 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+// Build sample model with additional entity referring to the user
+func diagramModel() *model.MetaModel {
+	mm := sampleModel()
+	session := model.NewClassInfo("Session", "User session")
+	session.TableName = "session"
+	session.Group = "Sessions"
+	session.AddField("Id", "string", "Session id")
+	session.AddField("UserId", "string", "The session user")
+	mm.AddClassInfo(session)
+	return mm
+}
+
+func TestDiagramProcessor(t *testing.T) {
+	outDir := t.TempDir()
+	require.Nil(t, processor.NewDiagramProcessor(diagramModel(), outDir).Start())
+
+	classes := readFile(t, path.Join(outDir, "class_diagram.puml"))
+	require.Contains(t, classes, "@startuml class_diagram\n")
+	require.Contains(t, classes, "class EntityResponse<T> {\n  +code : int\n  +data : T\n}\n")
+	require.Contains(t, classes, "class User <<Entity>> {\n")
+	require.Contains(t, classes, "  +roles : string[]\n")
+	require.Contains(t, classes, "enum UserStatusCode {\n  UNDEFINED\n  ACTIVE\n  BLOCKED\n}\n")
+	require.Contains(t, classes, "BaseEntity <|-- User\n")
+	require.Contains(t, classes, "UsersPage --> \"1\" User : current\n")
+
+	er := readFile(t, path.Join(outDir, "er_diagram.puml"))
+	require.Contains(t, er, "entity \"user\" as User {\n  * id : TEXT\n  --\n")
+	require.Contains(t, er, "  email : TEXT <<UK>>\n")
+	require.Contains(t, er, "  user_id : TEXT <<FK>>\n")
+	require.Contains(t, er, "Session }o--|| User : user_id\n")
+
+	mermaid := readFile(t, path.Join(outDir, "class_diagram.mmd"))
+	require.Contains(t, mermaid, "  class EntityResponse~T~ {\n")
+	require.Contains(t, mermaid, "    +EntityResponse~User~ current\n")
+
+	erMermaid := readFile(t, path.Join(outDir, "er_diagram.mmd"))
+	require.Contains(t, erMermaid, "  User[\"user\"] {\n    TEXT id PK\n")
+	require.Contains(t, erMermaid, "    TEXT user_id FK\n")
+	require.Contains(t, erMermaid, "  Session }o--|| User : \"user_id\"\n")
+
+	docs := readFile(t, path.Join(outDir, "diagrams.md"))
+	require.Contains(t, docs, "```mermaid\nclassDiagram\n")
+	require.Contains(t, docs, "```mermaid\nerDiagram\n")
+}
+
+func TestDiagramProcessorFilters(t *testing.T) {
+	// Group filter includes the classes used by the group services
+	outDir := t.TempDir()
+	require.Nil(t, processor.NewDiagramProcessor(diagramModel(), outDir).WithGroup("Users").Start())
+	classes := readFile(t, path.Join(outDir, "class_diagram.puml"))
+	require.Contains(t, classes, "class User <<Entity>> {")
+	require.Contains(t, classes, "class EntityResponse<T> {")
+	require.NotContains(t, classes, "Session")
+	require.NotContains(t, classes, "class UsersPage")
+
+	// Group filter includes the classes of the group
+	outDir = t.TempDir()
+	require.Nil(t, processor.NewDiagramProcessor(diagramModel(), outDir).WithGroup("Sessions").Start())
+	er := readFile(t, path.Join(outDir, "er_diagram.puml"))
+	require.Contains(t, er, "entity \"session\" as Session {")
+	require.NotContains(t, er, "entity \"user\"")
+
+	// Package filter
+	outDir = t.TempDir()
+	require.Nil(t, processor.NewDiagramProcessor(diagramModel(), outDir).WithPackage("other").Start())
+	require.NotContains(t, readFile(t, path.Join(outDir, "class_diagram.puml")), "class ")
+}
+
+func TestMarkdownDiagrams(t *testing.T) {
+	outDir := t.TempDir()
+	require.Nil(t, processor.NewMarkdownProcessor(diagramModel(), outDir).Start())
+
+	docs := readFile(t, path.Join(outDir, "diagrams.md"))
+	require.Contains(t, docs, "title: \"Data Model\"")
+	require.Contains(t, docs, "```mermaid\nclassDiagram\n")
+	require.Contains(t, docs, "  Session }o--|| User : \"user_id\"\n")
+	require.Contains(t, readFile(t, path.Join(outDir, "sidebars.js")), "    },\n    \"diagrams\",\n  ],")
+	require.Contains(t, readFile(t, path.Join(outDir, "mkdocs.nav.yml")), "\n  - \"Data Model\": \"diagrams.md\"\n")
+	require.Contains(t, readFile(t, path.Join(outDir, "index.md")), "See the [data model diagrams](diagrams.md).")
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+)
+
+func TestModelDiff(t *testing.T) {
+	prev := sampleModel()
+
+	// Same model has no changes
+	require.Empty(t, model.Diff(prev, sampleModel()))
+
+	// Compatible changes only
+	curr := sampleModel()
+	curr.GetClass("User").AddField("Phone", "string", "User phone")
+	curr.GetService("UsersService").Methods[1].AddQueryParam("sort | string | Sort order")
+	ev := model.NewEnumValueInfo("DELETED", "DELETED status")
+	ev.Value = 3
+	curr.GetEnum("UserStatusCode").AddValue(ev)
+
+	changes := model.Diff(prev, curr)
+	require.Len(t, changes, 3)
+	require.False(t, model.HasBreakingChanges(changes))
+	require.Equal(t, "User.phone", changes[1].Name)
+
+	// Breaking changes
+	curr = sampleModel()
+	user := curr.GetClass("User")
+	user.GetField("Email").Json = "mail"
+	user.GetField("Status").Type = "string"
+	curr.GetEnum("UserStatusCode").Values[2].Value = 5
+	svc := curr.GetService("UsersService")
+	svc.Methods[0].SetAction("GET /id/{userId}")
+	svc.Methods[2].SetAction("PUT /")
+	svc.Methods = svc.Methods[:4]
+
+	changes = model.Diff(prev, curr)
+	require.True(t, model.HasBreakingChanges(changes))
+
+	messages := make(map[string]string)
+	for _, c := range changes {
+		require.Equal(t, c.Name != "User.mail", c.Breaking, c.String())
+		messages[c.Name] += c.Message
+	}
+	require.Equal(t, "path changed from /users/{id} to /users/id/{userId}", messages["UsersService.Get"])
+	require.Equal(t, "HTTP verb changed from POST to PUT", messages["UsersService.Create"])
+	require.Equal(t, "endpoint POST /users/{id}/avatar removed", messages["UsersService.Upload"])
+	require.Equal(t, "field removed (renamed to mail?)", messages["User.email"])
+	require.Equal(t, "type changed from UserStatusCode to string", messages["User.status"])
+	require.Equal(t, "value renumbered from 2 to 5", messages["UserStatusCode.BLOCKED"])
+}
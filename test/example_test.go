@@ -0,0 +1,171 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	generator "github.com/go-yaaf/yaaf-code-gen"
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/parser"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+var exampleSource = `package api
+
+// Order status
+// @Enum
+type OrderStatusCode int
+
+// @EnumValuesFor: OrderStatusCode
+type orderStatusCodes struct {
+	// Undefined
+	UNDEFINED int ` + "`value:\"0\"`" + `
+	// New order
+	NEW int ` + "`value:\"1\"`" + `
+}
+
+// Order
+// @Data
+type Order struct {
+	// Order id
+	// @Example: ord-1001
+	Id string ` + "`json:\"id\"`" + `
+	// Customer contact
+	// @Format: email
+	Contact string ` + "`json:\"contact\"`" + `
+	// Order total
+	// @Example: 99.9
+	Total float64 ` + "`json:\"total\"`" + `
+	// Order status
+	Status OrderStatusCode ` + "`json:\"status\"`" + `
+	// Order lines
+	Lines []OrderLine ` + "`json:\"lines\"`" + `
+}
+
+// Order line
+// @Data
+type OrderLine struct {
+	// Product name
+	ProductName string ` + "`json:\"productName\"`" + `
+	// Quantity
+	Quantity int ` + "`json:\"quantity\"`" + `
+	// Parent order (recursive)
+	Order Order ` + "`json:\"order\"`" + `
+}
+
+// Orders management
+// @Service: OrdersService
+// @Path: /orders
+type OrdersService struct {
+}
+
+// Get order by id
+// @Http: GET /{id}
+// @Example: id | ord-2002
+// @PathParam: id | string | The order id
+// @Example: {"id": "ord-2002", "total": 10}
+// @Return: Order
+func (s *OrdersService) get() {}
+
+// Create order
+// @Http: POST /
+// @BodyParam: order | Order | The order
+// @Return: Order
+func (s *OrdersService) create() {}
+`
+
+func exampleModel(t *testing.T) *model.MetaModel {
+	srcDir := t.TempDir()
+	err := os.WriteFile(path.Join(srcDir, "api.go"), []byte(exampleSource), 0644)
+	require.Nil(t, err)
+
+	cg := generator.NewCodeGenerator().WithSourceFolder(srcDir, "")
+	require.Nil(t, cg.Parse())
+	return cg.Model
+}
+
+func TestExampleParser(t *testing.T) {
+	mm := exampleModel(t)
+
+	order := mm.GetClass("Order")
+	require.Equal(t, "ord-1001", order.GetField("Id").Example)
+	require.Equal(t, []string{"Order id"}, order.GetField("Id").Docs)
+	require.Equal(t, "99.9", order.GetField("Total").Example)
+
+	get := mm.GetService("OrdersService").Methods[0]
+	require.Equal(t, "ord-2002", get.PathParams[0].Example)
+	require.Equal(t, `{"id": "ord-2002", "total": 10}`, get.Example)
+
+	// Example of unknown parameter is reported and not used as the response example
+	mi := model.NewMethodInfo("Get")
+	mi.AddPathParam("id | string | The order id")
+	require.EqualError(t, mi.SetExample("orderId | ord-1"), "example of unknown parameter orderId")
+	require.Empty(t, mi.Example)
+	require.Empty(t, mi.PathParams[0].Example)
+	require.Nil(t, mi.SetExample(`{"status": "a|b"}`))
+	require.Equal(t, `{"status": "a|b"}`, mi.Example)
+
+	// The parser reports the unknown parameter as warning
+	srcDir := t.TempDir()
+	source := strings.Replace(exampleSource, "@Example: id | ord-2002", "@Example: orderId | ord-2002", 1)
+	require.Nil(t, os.WriteFile(path.Join(srcDir, "api.go"), []byte(source), 0644))
+	fileParser := parser.NewFileParser(model.NewMetaModel(), "")
+	require.Nil(t, fileParser.ParseFile(path.Join(srcDir, "api.go")))
+	require.Equal(t, []string{"OrdersService.Get: example of unknown parameter orderId"}, fileParser.Warnings)
+
+	// Examples are kept in the saved model
+	fileName := path.Join(t.TempDir(), "model.json")
+	require.Nil(t, model.Save(mm, fileName))
+	loaded, err := model.Load(fileName)
+	require.Nil(t, err)
+	require.Equal(t, "ord-1001", loaded.GetClass("Order").GetField("Id").Example)
+	require.Equal(t, "ord-2002", loaded.GetService("OrdersService").Methods[0].PathParams[0].Example)
+	require.Equal(t, get.Example, loaded.GetService("OrdersService").Methods[0].Example)
+}
+
+func TestExampleOutputs(t *testing.T) {
+	mm := exampleModel(t)
+
+	// Postman: path variable, synthesized body and explicit response example
+	outDir := t.TempDir()
+	require.Nil(t, processor.NewPostmanProcessor(mm, outDir).Start())
+
+	var collection map[string]any
+	require.Nil(t, json.Unmarshal([]byte(readFile(t, path.Join(outDir, "API.postman_collection.json"))), &collection))
+	requests := collection["item"].([]any)[0].(map[string]any)["item"].([]any)[0].(map[string]any)["item"].([]any)
+
+	get := requests[0].(map[string]any)
+	require.Equal(t, "ord-2002", get["request"].(map[string]any)["url"].(map[string]any)["variable"].([]any)[0].(map[string]any)["value"])
+	require.Equal(t, `{"id": "ord-2002", "total": 10}`, get["response"].([]any)[0].(map[string]any)["body"])
+
+	body := requests[1].(map[string]any)["request"].(map[string]any)["body"].(map[string]any)["raw"].(string)
+	var order map[string]any
+	require.Nil(t, json.Unmarshal([]byte(body), &order))
+	require.Equal(t, "ord-1001", order["id"])
+	require.Equal(t, "jane.doe@example.com", order["contact"])
+	require.Equal(t, 99.9, order["total"])
+	require.Equal(t, float64(1), order["status"])
+	line := order["lines"].([]any)[0].(map[string]any)
+	require.Equal(t, "Jane Doe", line["productName"])
+	require.Equal(t, float64(1), line["quantity"])
+	require.Nil(t, line["order"])
+
+	// HTML: explorer input and response example
+	htmlDir := t.TempDir()
+	require.Nil(t, processor.NewHtmlProcessor(mm, htmlDir).Start())
+	service := readFile(t, path.Join(htmlDir, "resource_OrdersService.html"))
+	require.Contains(t, service, `<input id="Get-id" name="id" data-in="path" placeholder="string" value="ord-2002" required>`)
+	require.Contains(t, service, `<pre class="response-example">{&#34;id&#34;: &#34;ord-2002&#34;, &#34;total&#34;: 10}</pre>`)
+
+	// JSON Schema
+	schemaDir := t.TempDir()
+	require.Nil(t, processor.NewJsonSchemaProcessor(mm, schemaDir).Start())
+	schema := readFile(t, path.Join(schemaDir, "Order.schema.json"))
+	require.Contains(t, schema, "\"examples\": [\n        \"ord-1001\"\n      ]")
+	require.Contains(t, schema, "\"examples\": [\n        99.9\n      ]")
+}
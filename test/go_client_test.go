@@ -0,0 +1,40 @@
+package test
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestGoClientProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewGoClientProcessor(sampleModel(), outDir, "client", "example.com/api/model").Start()
+	require.Nil(t, err)
+
+	// The generated files are valid Go source
+	for _, fileName := range []string{"client.go", "users_service_client.go"} {
+		_, err = parser.ParseFile(token.NewFileSet(), path.Join(outDir, fileName), nil, 0)
+		require.Nil(t, err)
+	}
+
+	client := readFile(t, path.Join(outDir, "client.go"))
+	require.Contains(t, client, "package client")
+	require.Contains(t, client, "func NewClient(baseURL string) *Client {")
+	require.Contains(t, client, "func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, file io.Reader, out any) error {")
+
+	service := readFile(t, path.Join(outDir, "users_service_client.go"))
+	require.Contains(t, service, "\tmodel \"example.com/api/model\"")
+	require.Contains(t, service, "// Expected HTTP headers (set them in Client.Headers): X-API-KEY\ntype UsersServiceClient struct {")
+	require.Contains(t, service, "func (c *UsersServiceClient) Get(ctx context.Context, id string) (*model.EntityResponse[model.User], error) {\n\tpath := \"/users/{id}\"\n\tpath = strings.ReplaceAll(path, \"{id}\", url.PathEscape(fmt.Sprint(id)))")
+	require.Contains(t, service, "func (c *UsersServiceClient) Find(ctx context.Context, search *string, status []model.UserStatusCode, page *int) (*model.EntitiesResponse[model.User], error) {\n\tpath := \"/users\"\n\tquery := url.Values{}\n\taddQueryParam(query, \"search\", search)")
+	require.Contains(t, service, "\tif err := c.Do(ctx, \"GET\", path, query, nil, nil, out); err != nil {")
+	require.Contains(t, service, "\tif err := c.Do(ctx, \"POST\", path, nil, user, nil, out); err != nil {")
+	require.Contains(t, service, "func (c *UsersServiceClient) Upload(ctx context.Context, file io.Reader, id string) (*model.ActionResponse, error) {")
+	require.Contains(t, service, "\tif err := c.Do(ctx, \"POST\", path, nil, nil, file, out); err != nil {")
+}
@@ -0,0 +1,35 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestGoServerProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewGoServerProcessor(sampleModel(), outDir, "server", "example.com/api/model").Start()
+	require.Nil(t, err)
+
+	server := readFile(t, path.Join(outDir, "server.go"))
+	require.Contains(t, server, "package server")
+	require.Contains(t, server, "type Router interface {\n\tHandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))\n}")
+	require.Contains(t, server, "func parseParam(text string, target any) error {")
+
+	routes := readFile(t, path.Join(outDir, "users_service_routes.go"))
+	require.Contains(t, routes, "\tmodel \"example.com/api/model\"")
+	require.Contains(t, routes, "type UsersServiceHandler interface {")
+	require.Contains(t, routes, "\tGet(ctx context.Context, id string) (*model.EntityResponse[model.User], error)")
+	require.Contains(t, routes, "\tFind(ctx context.Context, search *string, status []model.UserStatusCode, page *int) (*model.EntitiesResponse[model.User], error)")
+	require.Contains(t, routes, "\tUpload(ctx context.Context, file io.Reader, id string) (*model.ActionResponse, error)")
+	require.Contains(t, routes, "func RegisterUsersServiceRoutes(router Router, service UsersServiceHandler) {")
+	require.Contains(t, routes, "\trouter.HandleFunc(\"GET /users/{id}\", func(w http.ResponseWriter, r *http.Request) {\n\t\tvar id string\n\t\tif err := parseParam(r.PathValue(\"id\"), &id); err != nil {")
+	require.Contains(t, routes, "\t\tvar page *int\n\t\tif text := r.URL.Query().Get(\"page\"); len(text) > 0 {\n\t\t\tpage = new(int)\n\t\t\tif err := parseParam(text, page); err != nil {")
+	require.Contains(t, routes, "\trouter.HandleFunc(\"POST /users\", func(w http.ResponseWriter, r *http.Request) {\n\t\tvar user model.User\n\t\tif err := json.NewDecoder(r.Body).Decode(&user); err != nil {")
+	require.Contains(t, routes, "\t\tfile, _, err := r.FormFile(\"fileKey\")")
+	require.Contains(t, routes, "\t\tresult, err := service.Upload(r.Context(), file, id)")
+}
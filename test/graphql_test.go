@@ -0,0 +1,64 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestGraphQLProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewGraphQLProcessor(sampleModel(), outDir).Start()
+	require.Nil(t, err)
+
+	schema := readFile(t, path.Join(outDir, "schema.graphql"))
+	require.Contains(t, schema, "\"\"\"64 bit integer\"\"\"\nscalar Long\n")
+	require.Contains(t, schema, "\"\"\"File upload (multipart request)\"\"\"\nscalar Upload\n")
+	require.Contains(t, schema, "enum UserStatusCode {\n  \"\"\"UNDEFINED status\"\"\"\n  UNDEFINED\n")
+
+	// Classes are flattened (base fields first) and generic classes are named by their type arguments
+	require.Contains(t, schema, "type User {\n  \"\"\"Unique object Id\"\"\"\n  id: String\n")
+	require.Contains(t, schema, "  status: UserStatusCode\n  \"\"\"User roles\"\"\"\n  roles: [String]\n")
+	require.Contains(t, schema, "  current: EntityResponseUser\n")
+	require.Contains(t, schema, "type EntityResponseUser {\n  \"\"\"Error code (0 for success)\"\"\"\n  code: Long\n  \"\"\"Entity\"\"\"\n  data: User\n}")
+	require.Contains(t, schema, "input UserInput {\n")
+
+	// GET methods are queries, other methods are mutations
+	require.Contains(t, schema, "type Query {\n  \"\"\"\n  Get single user by id\n\n  HTTP: GET /users/{id}\n  \"\"\"\n  get(\n    \"\"\"The user id\"\"\"\n    id: String!\n  ): EntityResponseUser\n")
+	require.Contains(t, schema, "    status: [UserStatusCode]\n")
+	require.Contains(t, schema, "type Mutation {\n")
+	require.Contains(t, schema, "  create(\n    \"\"\"The user to create\"\"\"\n    user: UserInput!\n  ): EntityResponseUser\n")
+	require.Contains(t, schema, "  HTTP: POST /users/{id}/avatar\n")
+	require.Contains(t, schema, "    file: Upload!\n")
+}
+
+func TestGraphQLProcessorEdgeCases(t *testing.T) {
+	outDir := t.TempDir()
+
+	mm := sampleModel()
+	mm.AddClassInfo(model.NewClassInfo("Empty", "Class without fields"))
+	svc := mm.ListServices()[0]
+	remove := svc.Methods[3]
+	require.Equal(t, "Delete", remove.Name)
+	remove.AddBodyParam("ids | []string | Users to delete")
+	remove.Deprecated = &model.DeprecationInfo{Reason: "use \"remove\" \\ purge\tinstead"}
+
+	err := processor.NewGraphQLProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+	schema := readFile(t, path.Join(outDir, "schema.graphql"))
+
+	// Type without fields gets a placeholder field
+	require.Contains(t, schema, "type Empty {\n  \"\"\"Placeholder of class without fields\"\"\"\n  _empty: Boolean\n}")
+
+	// Body is not sent with DELETE requests
+	require.Contains(t, schema, "  delete(\n    \"\"\"The user id\"\"\"\n    id: String!\n  ): JSON @deprecated")
+	require.NotContains(t, schema, "ids:")
+
+	// Deprecation reason is GraphQL string literal
+	require.Contains(t, schema, `@deprecated(reason: "use \"remove\" \\ purge\u0009instead")`)
+}
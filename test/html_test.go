@@ -0,0 +1,87 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestHtmlProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewHtmlProcessor(sampleModel(), outDir).Start()
+	require.Nil(t, err)
+
+	require.FileExists(t, path.Join(outDir, "style.css"))
+	require.FileExists(t, path.Join(outDir, "img", "logo.svg"))
+	require.NoFileExists(t, path.Join(outDir, "webSockets.html"))
+
+	index := readFile(t, path.Join(outDir, "index.html"))
+	require.Contains(t, index, `<h2 id="Users">Users</h2>`)
+	require.Contains(t, index, `<a href="resource_UsersService.html#Get"><span class="label label-default resource-method">GET</span> <samp class="resource-path">/users/{id}</samp></a>`)
+	require.NotContains(t, index, "webSockets.html")
+
+	service := readFile(t, path.Join(outDir, "resource_UsersService.html"))
+	require.Contains(t, service, `<section class="method" id="Get">`)
+	require.Contains(t, service, `<a href="json_EntityResponse.html">EntityResponse</a>&lt;<a href="json_User.html">User</a>&gt;`)
+	require.Contains(t, service, "<code>X-API-KEY</code>")
+
+	user := readFile(t, path.Join(outDir, "json_User.html"))
+	require.Contains(t, user, `<a href="json_UserStatusCode.html">UserStatusCode</a>`)
+	require.Contains(t, user, `<span class="property-name">email</span>`)
+
+	require.FileExists(t, path.Join(outDir, "json_UserStatusCode.html"))
+	require.Contains(t, readFile(t, path.Join(outDir, "dataTypes.html")), `<a href="json_User.html">User</a>`)
+	require.Contains(t, readFile(t, path.Join(outDir, "enums.html")), `<a href="json_UserStatusCode.html">UserStatusCode</a>`)
+}
+
+func TestHtmlProcessorExplorer(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewHtmlProcessor(sampleModel(), outDir).WithBaseURL("https://api.example.com").Start()
+	require.Nil(t, err)
+	require.FileExists(t, path.Join(outDir, "explorer.js"))
+
+	service := readFile(t, path.Join(outDir, "resource_UsersService.html"))
+	require.Contains(t, service, `<input id="explorer-base-url" type="url" value="https://api.example.com"`)
+	require.Contains(t, service, `<form class="try-it" data-method="GET" data-path="/users/{id}">`)
+	require.Contains(t, service, `<input id="Get-id" name="id" data-in="path" placeholder="string" required>`)
+	require.Contains(t, service, `<input id="Find-search" name="search" data-in="query" placeholder="string">`)
+	require.Contains(t, service, `<input id="Create-X-API-KEY" name="X-API-KEY" data-in="header">`)
+	require.Contains(t, service, `<textarea id="Create-user" name="user" data-in="body" rows="8" spellcheck="false">{
+  &#34;id&#34;: &#34;a1b2c3d4&#34;,`)
+	require.Contains(t, service, `&#34;email&#34;: &#34;jane.doe@example.com&#34;,`)
+	require.Contains(t, service, `name="file" type="file" data-in="file" required>`)
+}
+
+func TestHtmlProcessorExplorerWithoutDeleteBody(t *testing.T) {
+	outDir := t.TempDir()
+
+	mm := sampleModel()
+	remove := mm.ListServices()[0].Methods[3]
+	require.Equal(t, "Delete", remove.Name)
+	remove.AddBodyParam("ids | []string | Users to delete")
+
+	err := processor.NewHtmlProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+
+	service := readFile(t, path.Join(outDir, "resource_UsersService.html"))
+	require.Contains(t, service, `<form class="try-it" data-method="DELETE" data-path="/users/{id}">`)
+	require.NotContains(t, service, `id="Delete-ids"`)
+	require.Contains(t, readFile(t, path.Join(outDir, "explorer.js")), "var hasBody = request.method !== 'GET' && request.method !== 'DELETE';")
+}
+
+func TestHtmlProcessorDeprecation(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewHtmlProcessor(deprecatedModel(t), outDir).Start()
+	require.Nil(t, err)
+
+	badge := `<span class="label label-warning deprecated" title="use contacts; deprecated since v2.1">deprecated</span>`
+	require.Contains(t, readFile(t, path.Join(outDir, "json_Account.html")), badge)
+	require.Contains(t, readFile(t, path.Join(outDir, "resource_AccountsService.html")), `title="no longer supported">deprecated</span>`)
+	require.Contains(t, readFile(t, path.Join(outDir, "dataTypes.html")), `title="use Account; deprecated since v2.0; use Account instead">deprecated</span>`)
+}
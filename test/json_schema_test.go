@@ -0,0 +1,83 @@
+package test
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestJsonSchemaProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewJsonSchemaProcessor(sampleModel(), outDir).Start()
+	require.Nil(t, err)
+
+	// Check class schema
+	user := make(map[string]any)
+	err = json.Unmarshal([]byte(readFile(t, path.Join(outDir, "User.schema.json"))), &user)
+	require.Nil(t, err)
+	require.Equal(t, "https://json-schema.org/draft/2020-12/schema", user["$schema"])
+	require.Equal(t, "User.schema.json", user["$id"])
+
+	props := user["properties"].(map[string]any)
+	require.Equal(t, "email", props["email"].(map[string]any)["format"])
+	require.Equal(t, "UserStatusCode.schema.json", props["status"].(map[string]any)["$ref"])
+	require.Equal(t, "array", props["roles"].(map[string]any)["type"])
+	require.Equal(t, "object", props["props"].(map[string]any)["type"])
+	require.Equal(t, "BaseEntity.schema.json", user["allOf"].([]any)[0].(map[string]any)["$ref"])
+
+	// Check generics binding
+	page := make(map[string]any)
+	err = json.Unmarshal([]byte(readFile(t, path.Join(outDir, "UsersPage.schema.json"))), &page)
+	require.Nil(t, err)
+	current := page["properties"].(map[string]any)["current"].(map[string]any)
+	require.Equal(t, "EntityResponse.schema.json", current["$ref"])
+	binding := current["$defs"].(map[string]any)["T"].(map[string]any)
+	require.Equal(t, "T", binding["$dynamicAnchor"])
+	require.Equal(t, "User.schema.json", binding["$ref"])
+
+	// Check enum schema
+	status := make(map[string]any)
+	err = json.Unmarshal([]byte(readFile(t, path.Join(outDir, "UserStatusCode.schema.json"))), &status)
+	require.Nil(t, err)
+	require.Equal(t, "integer", status["type"])
+	require.Len(t, status["oneOf"], 3)
+
+	// Check bundle
+	bundle := make(map[string]any)
+	err = json.Unmarshal([]byte(readFile(t, path.Join(outDir, "defs.schema.json"))), &bundle)
+	require.Nil(t, err)
+	require.Len(t, bundle["$defs"], 5)
+}
+
+func TestJsonSchemaProcessorFormats(t *testing.T) {
+	outDir := t.TempDir()
+
+	mm := sampleModel()
+	user := mm.GetClass("User")
+	user.AddField("LastLogin", "string", "Last login time")
+	user.GetField("LastLogin").Format = "datetime"
+	user.AddField("Balance", "string", "Account balance")
+	user.GetField("Balance").Format = "decimal"
+
+	err := processor.NewJsonSchemaProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+
+	// Known formats are mapped to JSON Schema formats, other formats are omitted
+	schema := make(map[string]any)
+	require.Nil(t, json.Unmarshal([]byte(readFile(t, path.Join(outDir, "User.schema.json"))), &schema))
+	props := schema["properties"].(map[string]any)
+	require.Equal(t, "date-time", props["lastLogin"].(map[string]any)["format"])
+	require.NotContains(t, props["balance"], "format")
+
+	// Timestamp is epoch milliseconds (integer), string formats are not used
+	base := make(map[string]any)
+	require.Nil(t, json.Unmarshal([]byte(readFile(t, path.Join(outDir, "BaseEntity.schema.json"))), &base))
+	createdOn := base["properties"].(map[string]any)["createdOn"].(map[string]any)
+	require.Equal(t, "integer", createdOn["type"])
+	require.NotContains(t, createdOn, "format")
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestKotlinProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewKotlinProcessor(sampleModel(), outDir, "com.acme.api").Start()
+	require.Nil(t, err)
+	folder := path.Join(outDir, "com", "acme", "api")
+
+	enum := readFile(t, path.Join(folder, "model", "UserStatusCode.kt"))
+	require.Contains(t, enum, "package com.acme.api.model")
+	require.Contains(t, enum, "@Serializable(with = UserStatusCodeSerializer::class)\nenum class UserStatusCode(val value: Int) {")
+	require.Contains(t, enum, "    BLOCKED(2);\n")
+	require.Contains(t, enum, "    override fun toString(): String = value.toString()")
+
+	user := readFile(t, path.Join(folder, "model", "User.kt"))
+	require.Contains(t, user, "@Serializable\ndata class User(\n    /** Unique object Id */\n    @SerialName(\"id\") val id: String? = null,")
+	require.Contains(t, user, "    @SerialName(\"createdOn\") val createdOn: Long? = null,")
+	require.Contains(t, user, "    @SerialName(\"status\") val status: UserStatusCode? = null,")
+	require.Contains(t, user, "    @SerialName(\"roles\") val roles: List<String>? = null,")
+	require.Contains(t, user, "    @SerialName(\"props\") val props: JsonObject? = null,")
+
+	require.Contains(t, readFile(t, path.Join(folder, "model", "EntityResponse.kt")), "data class EntityResponse<T>(")
+	require.Contains(t, readFile(t, path.Join(folder, "model", "UsersPage.kt")), "    @SerialName(\"current\") val current: EntityResponse<User>? = null,")
+
+	queryList := readFile(t, path.Join(folder, "api", "QueryList.kt"))
+	require.Contains(t, queryList, "package com.acme.api.api")
+	require.Contains(t, queryList, "class QueryList<T>(val items: List<T>) {\n    override fun toString(): String = items.joinToString(\",\")\n}")
+
+	service := readFile(t, path.Join(folder, "api", "UsersService.kt"))
+	require.Contains(t, service, "// Expected HTTP headers (add them using OkHttp interceptor): X-API-KEY")
+	require.Contains(t, service, "interface UsersService {")
+	require.Contains(t, service, "    @GET(\"users/{id}\")\n    suspend fun get(@Path(\"id\") id: String): EntityResponse<User>")
+	require.Contains(t, service, "    @GET(\"users\")\n    suspend fun find(@Query(\"search\") search: String? = null, @Query(\"status\") status: QueryList<UserStatusCode>? = null, @Query(\"page\") page: Int? = null): JsonElement")
+	require.Contains(t, service, "    @POST(\"users\")\n    suspend fun create(@Body user: User): EntityResponse<User>")
+	require.Contains(t, service, "    @Multipart\n    @POST(\"users/{id}/avatar\")\n    suspend fun upload(@Part file: MultipartBody.Part, @Path(\"id\") id: String): JsonElement")
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestMarkdownProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewMarkdownProcessor(sampleModel(), outDir).WithPrefix("/api/").Start()
+	require.Nil(t, err)
+
+	service := readFile(t, path.Join(outDir, "services", "UsersService.md"))
+	require.Contains(t, service, "---\ntitle: \"UsersService\"\nsidebar_label: \"UsersService\"\ndescription: \"Users management\"\n---\n")
+	require.Contains(t, service, "| `GET` | [`/users/{id}`](#get) | Get single user by id |")
+	require.Contains(t, service, "| `status` | query | \\[\\][UserStatusCode](../enums/UserStatusCode.md) | Filter by status |")
+	require.Contains(t, service, "[EntityResponse](../classes/EntityResponse.md)&lt;[User](../classes/User.md)&gt;")
+
+	user := readFile(t, path.Join(outDir, "classes", "User.md"))
+	require.Contains(t, user, "Extends [BaseEntity](../classes/BaseEntity.md)")
+	require.Contains(t, user, "| `id` | string | Unique object Id |")
+	require.Contains(t, user, "| `roles` | \\[\\]string | User roles |")
+	require.Contains(t, user, "- [UsersService](../services/UsersService.md)")
+
+	status := readFile(t, path.Join(outDir, "enums", "UserStatusCode.md"))
+	require.Contains(t, status, "| `ACTIVE` | 1 | ACTIVE status |")
+	require.Contains(t, status, "- [User](../classes/User.md)")
+
+	index := readFile(t, path.Join(outDir, "index.md"))
+	require.Contains(t, index, "## Users")
+	require.Contains(t, index, "[`GET /users/{id}`](services/UsersService.md#get)")
+	require.Contains(t, index, "| [User](classes/User.md) | User entity |")
+
+	sidebars := readFile(t, path.Join(outDir, "sidebars.js"))
+	require.Contains(t, sidebars, `"api/index",`)
+	require.Contains(t, sidebars, `label: "Users",`+"\n"+`      items: ["api/services/UsersService"],`)
+	require.Contains(t, sidebars, `"api/classes/BaseEntity", "api/classes/EntityResponse"`)
+
+	nav := readFile(t, path.Join(outDir, "mkdocs.nav.yml"))
+	require.Contains(t, nav, "  - \"Overview\": \"api/index.md\"\n  - \"Users\":\n      - \"UsersService\": \"api/services/UsersService.md\"\n")
+	require.Contains(t, nav, "  - \"Enums\":\n      - \"UserStatusCode\": \"api/enums/UserStatusCode.md\"\n")
+}
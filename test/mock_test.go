@@ -0,0 +1,110 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/mock"
+)
+
+// Send request to the mock server and return the response
+func mockRequest(handler http.Handler, method string, target string, contentType string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMockServer(t *testing.T) {
+	server := mock.NewServer(sampleModel())
+
+	// example response of the return type
+	rec := mockRequest(server, "GET", "/users/u1", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	var entity map[string]any
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &entity))
+	require.Equal(t, float64(1), entity["code"])
+	require.Equal(t, "jane.doe@example.com", entity["data"].(map[string]any)["email"])
+
+	// routing errors
+	require.Equal(t, http.StatusNotFound, mockRequest(server, "GET", "/orders", "", "").Code)
+	rec = mockRequest(server, "PUT", "/users/u1", "", "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, "GET, DELETE", rec.Header().Get("Allow"))
+
+	// CORS preflight
+	rec = mockRequest(server, "OPTIONS", "/users/u1", "", "")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, "GET, DELETE, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+
+	// query parameters
+	require.Equal(t, http.StatusOK, mockRequest(server, "GET", "/users?search=jane&status=1,2&page=2", "", "").Code)
+	rec = mockRequest(server, "GET", "/users?page=first", "", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "query parameter page: invalid int value: first")
+	rec = mockRequest(server, "GET", "/users?status=1,7", "", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "invalid UserStatusCode value: 7")
+
+	// body parameter
+	user := `{"id": "u1", "name": "Jane", "email": "jane@example.com", "status": 1, "roles": ["admin"], "props": {"a": 1}}`
+	require.Equal(t, http.StatusOK, mockRequest(server, "POST", "/users", "application/json", user).Code)
+	rec = mockRequest(server, "POST", "/users", "application/json", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "body parameter user is required")
+	rec = mockRequest(server, "POST", "/users", "application/json", `{"name": 5}`)
+	require.Contains(t, rec.Body.String(), "body user.name: string expected")
+	rec = mockRequest(server, "POST", "/users", "application/json", `{"roles": "admin"}`)
+	require.Contains(t, rec.Body.String(), "body user.roles: array expected")
+	rec = mockRequest(server, "POST", "/users", "application/json", `{"nickname": "jd"}`)
+	require.Contains(t, rec.Body.String(), "body user: unknown field nickname")
+	rec = mockRequest(server, "POST", "/users", "application/json", `{"status": 3}`)
+	require.Contains(t, rec.Body.String(), "body user.status: invalid UserStatusCode value: 3")
+
+	// file parameter
+	rec = mockRequest(server, "POST", "/users/u1/avatar", "application/json", "{}")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, _ := writer.CreateFormFile("fileKey", "avatar.png")
+	_, _ = part.Write([]byte("image"))
+	_ = writer.Close()
+	rec = mockRequest(server, "POST", "/users/u1/avatar", writer.FormDataContentType(), form.String())
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMockServerWithoutDeleteBody(t *testing.T) {
+	mm := sampleModel()
+	remove := mm.ListServices()[0].Methods[3]
+	require.Equal(t, "Delete", remove.Name)
+	remove.AddBodyParam("ids | []string | Users to delete")
+
+	// body is not sent with DELETE requests, so it is not required
+	rec := mockRequest(mock.NewServer(mm), "DELETE", "/users/u1", "", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
+
+func TestMockServerRandom(t *testing.T) {
+	first := mockRequest(mock.NewServer(sampleModel()).WithRandom(7), "GET", "/users/u1", "", "").Body.String()
+	second := mockRequest(mock.NewServer(sampleModel()).WithRandom(7), "GET", "/users/u1", "", "").Body.String()
+	require.Equal(t, first, second)
+
+	var entity map[string]any
+	require.Nil(t, json.Unmarshal([]byte(first), &entity))
+	user := entity["data"].(map[string]any)
+	require.NotEqual(t, "jane.doe@example.com", user["email"])
+	require.Contains(t, user["email"], "@example.com")
+	require.Contains(t, []any{float64(0), float64(1), float64(2)}, user["status"])
+}
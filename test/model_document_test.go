@@ -0,0 +1,44 @@
+package test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	generator "github.com/go-yaaf/yaaf-code-gen"
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestModelSaveLoad(t *testing.T) {
+	outDir := t.TempDir()
+	mm := sampleModel()
+
+	for _, fileName := range []string{"model.json", "model.yaml"} {
+		modelFile := path.Join(outDir, fileName)
+		require.Nil(t, model.Save(mm, modelFile))
+
+		loaded, err := model.Load(modelFile)
+		require.Nil(t, err)
+		require.Equal(t, mm.Document(), loaded.Document())
+		require.Empty(t, model.Diff(mm, loaded))
+	}
+	require.Contains(t, readFile(t, path.Join(outDir, "model.yaml")), "version: 1\n")
+
+	// Processors output of the loaded model is the same as the output of the original model
+	err := processor.NewSqlProcessor(mm, path.Join(outDir, "parsed")).Start()
+	require.Nil(t, err)
+
+	cg := generator.NewCodeGenerator().WithModelFile(path.Join(outDir, "model.yaml"))
+	cg.WithProcessor(processor.NewSqlProcessor(cg.Model, path.Join(outDir, "loaded")))
+	require.Nil(t, cg.Process())
+	require.Equal(t, readFile(t, path.Join(outDir, "parsed", "schema.sql")), readFile(t, path.Join(outDir, "loaded", "schema.sql")))
+
+	// Unknown format version
+	modelFile := path.Join(outDir, "future.json")
+	require.Nil(t, os.WriteFile(modelFile, []byte(`{"version": 99, "packages": []}`), 0644))
+	_, err = model.Load(modelFile)
+	require.ErrorContains(t, err, "unsupported model format version: 99")
+}
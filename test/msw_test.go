@@ -0,0 +1,37 @@
+package test
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestMswProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewMswProcessor(sampleModel(), outDir).WithModelImport("@app/model").Start()
+	require.Nil(t, err)
+
+	fixtures := readFile(t, path.Join(outDir, "fixtures.ts"))
+	require.True(t, strings.HasPrefix(fixtures, "// Code generated by yaaf-code-gen. DO NOT EDIT.\n"))
+	require.Contains(t, fixtures, "import { BaseEntity, EntityResponse, User, UserStatusCode, UsersPage } from '@app/model';")
+	require.Contains(t, fixtures, "export function createUser(overrides: Partial<User> = {}): User {")
+	require.Contains(t, fixtures, "    ...createBaseEntity(),\n    name: \"Jane Doe\",")
+	require.Contains(t, fixtures, "    status: UserStatusCode.ACTIVE,\n    roles: [],\n    props: {},\n    ...overrides,\n  } as User;")
+	require.Contains(t, fixtures, "export function createEntityResponse<T = any>(overrides: Partial<EntityResponse<T>> = {}): EntityResponse<T> {")
+	require.Contains(t, fixtures, "    data: null as unknown as T,")
+	require.Contains(t, fixtures, "    current: createEntityResponse(),\n    total: 1,")
+
+	handlers := readFile(t, path.Join(outDir, "handlers.ts"))
+	require.True(t, strings.HasPrefix(handlers, "// Code generated by yaaf-code-gen. DO NOT EDIT.\n"))
+	require.Contains(t, handlers, "import { createEntityResponse, createUser } from './fixtures';")
+	require.Contains(t, handlers, "export const usersServiceHandlers = [")
+	require.Contains(t, handlers, "  // Get single user by id\n  http.get('*/users/:id', () => HttpResponse.json(createEntityResponse({ data: createUser() }))),")
+	require.Contains(t, handlers, "  http.get('*/users', () => HttpResponse.json({})),")
+	require.Contains(t, handlers, "  http.post('*/users/:id/avatar', () => HttpResponse.json({})),")
+	require.Contains(t, handlers, "export const handlers = [\n  ...usersServiceHandlers,\n];")
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestPostmanProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewPostmanProcessor(sampleModel(), outDir).WithName("Users API").WithBaseURL("https://api.example.com").Start()
+	require.Nil(t, err)
+
+	var collection map[string]any
+	require.Nil(t, json.Unmarshal([]byte(readFile(t, path.Join(outDir, "Users_API.postman_collection.json"))), &collection))
+	require.Equal(t, "https://schema.getpostman.com/json/collection/v2.1.0/collection.json", collection["info"].(map[string]any)["schema"])
+
+	group := collection["item"].([]any)[0].(map[string]any)
+	require.Equal(t, "Users", group["name"])
+	service := group["item"].([]any)[0].(map[string]any)
+	require.Equal(t, "UsersService", service["name"])
+
+	requests := service["item"].([]any)
+	get := requests[0].(map[string]any)["request"].(map[string]any)
+	require.Equal(t, "GET", get["method"])
+	require.Equal(t, "{{baseUrl}}/users/:id", get["url"].(map[string]any)["raw"])
+	require.Equal(t, []any{"users", ":id"}, get["url"].(map[string]any)["path"])
+	require.Equal(t, map[string]any{"key": "X-API-KEY", "value": "{{xApiKey}}"}, get["header"].([]any)[0])
+
+	find := requests[1].(map[string]any)["request"].(map[string]any)
+	require.Equal(t, "{{baseUrl}}/users?search=search&status=1&page=1", find["url"].(map[string]any)["raw"])
+
+	create := requests[2].(map[string]any)["request"].(map[string]any)
+	body := create["body"].(map[string]any)
+	require.Equal(t, "raw", body["mode"])
+	require.Contains(t, body["raw"], "  \"name\": \"Jane Doe\",\n  \"email\": \"jane.doe@example.com\",\n  \"status\": 1,")
+
+	upload := requests[4].(map[string]any)["request"].(map[string]any)
+	require.Equal(t, "formdata", upload["body"].(map[string]any)["mode"])
+
+	environment := readFile(t, path.Join(outDir, "Users_API.postman_environment.json"))
+	require.Contains(t, environment, "\"key\": \"baseUrl\",\n      \"value\": \"https://api.example.com\",")
+	require.Contains(t, environment, "\"key\": \"xApiKey\",")
+
+	require.Contains(t, readFile(t, path.Join(outDir, "http", "http-client.env.json")), "\"xApiKey\": \"\"")
+
+	http := readFile(t, path.Join(outDir, "http", "Users.http"))
+	require.Contains(t, http, "@id = a1b2c3d4\n")
+	require.Contains(t, http, "### UsersService.get - Get single user by id\nGET {{baseUrl}}/users/{{id}}\nX-API-KEY: {{xApiKey}}\n")
+	require.Contains(t, http, "POST {{baseUrl}}/users\nX-API-KEY: {{xApiKey}}\nContent-Type: application/json\n\n{\n  \"id\": \"a1b2c3d4\",")
+	require.Contains(t, http, "Content-Disposition: form-data; name=\"fileKey\"; filename=\"file\"")
+}
+
+func TestPostmanProcessorWithoutGetAndDeleteBody(t *testing.T) {
+	outDir := t.TempDir()
+
+	mm := sampleModel()
+	remove := mm.ListServices()[0].Methods[3]
+	require.Equal(t, "Delete", remove.Name)
+	remove.AddBodyParam("ids | []string | Users to delete")
+
+	err := processor.NewPostmanProcessor(mm, outDir).WithName("Users API").Start()
+	require.Nil(t, err)
+
+	var collection map[string]any
+	require.Nil(t, json.Unmarshal([]byte(readFile(t, path.Join(outDir, "Users_API.postman_collection.json"))), &collection))
+	requests := collection["item"].([]any)[0].(map[string]any)["item"].([]any)[0].(map[string]any)["item"].([]any)
+	request := requests[3].(map[string]any)["request"].(map[string]any)
+	require.Equal(t, "DELETE", request["method"])
+	require.NotContains(t, request, "body")
+
+	http := readFile(t, path.Join(outDir, "http", "Users.http"))
+	require.Contains(t, http, "DELETE {{baseUrl}}/users/{{id}}\nX-API-KEY: {{xApiKey}}\n\n")
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestProtobufProcessor(t *testing.T) {
+	outDir := t.TempDir()
+	protoFile := path.Join(outDir, "sample.api.v1.proto")
+
+	mm := sampleModel()
+	err := processor.NewProtobufProcessor(mm, outDir, "sample.api.v1").Start()
+	require.Nil(t, err)
+
+	content := readFile(t, protoFile)
+	require.Contains(t, content, "package sample.api.v1;")
+	require.Contains(t, content, "USER_STATUS_CODE_ACTIVE = 1;")
+	require.Contains(t, content, "string email = 4;")
+	require.Contains(t, content, "repeated string roles = 6;")
+	require.Contains(t, content, "message EntityResponseUser {")
+	require.Contains(t, content, "User data = 2;")
+	require.Contains(t, content, "rpc Get(UsersServiceGetRequest) returns (EntityResponseUser);")
+	require.NotContains(t, content, "google/protobuf/empty.proto")
+
+	// Remove field and add new one, existing field numbers must not change
+	user := mm.GetClass("User")
+	fields := make([]*model.FieldInfo, 0)
+	for _, fi := range user.Fields {
+		if fi.Name != "Email" {
+			fields = append(fields, fi)
+		}
+	}
+	user.Fields = fields
+	user.AddField("Phone", "string", "User phone")
+
+	err = processor.NewProtobufProcessor(mm, outDir, "sample.api.v1").Start()
+	require.Nil(t, err)
+
+	content = readFile(t, protoFile)
+	require.Contains(t, content, "reserved 4;")
+	require.Contains(t, content, `reserved "email";`)
+	require.Contains(t, content, "UserStatusCode status = 5;")
+	require.Contains(t, content, "string phone = 8;")
+	require.NotContains(t, content, "string email")
+}
+
+func TestProtobufProcessorEmpty(t *testing.T) {
+	outDir := t.TempDir()
+
+	mm := sampleModel()
+	ping := model.NewMethodInfo("Ping")
+	ping.SetAction("POST /ping")
+	mm.ListServices()[0].Methods = append(mm.ListServices()[0].Methods, ping)
+
+	err := processor.NewProtobufProcessor(mm, outDir, "sample.api.v1").Start()
+	require.Nil(t, err)
+
+	content := readFile(t, path.Join(outDir, "sample.api.v1.proto"))
+	require.Contains(t, content, "import \"google/protobuf/empty.proto\";")
+	require.Contains(t, content, "rpc Ping(google.protobuf.Empty) returns (google.protobuf.Empty);")
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestPythonProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewPythonProcessor(sampleModel(), outDir).Start()
+	require.Nil(t, err)
+	require.FileExists(t, path.Join(outDir, "__init__.py"))
+
+	models := readFile(t, path.Join(outDir, "models.py"))
+	require.Contains(t, models, "class UserStatusCode(IntEnum):\n    \"\"\"User status code\"\"\"\n\n    UNDEFINED = 0  # UNDEFINED status")
+	require.Contains(t, models, "class EntityResponse(BaseModel, Generic[T]):")
+	require.Contains(t, models, "class User(BaseEntity):")
+	require.Contains(t, models, "    created_on: Optional[int] = Field(default=None, alias=\"createdOn\", description=\"When the object was created\")")
+	require.Contains(t, models, "    roles: Optional[List[str]] = Field(default=None, alias=\"roles\", description=\"User roles\")")
+	require.Contains(t, models, "    current: Optional[EntityResponse[User]] = Field(default=None, alias=\"current\", description=\"Current user\")")
+
+	services := readFile(t, path.Join(outDir, "services.py"))
+	require.Contains(t, services, "from urllib.parse import quote")
+	require.Contains(t, services, "class UsersService:")
+	require.Contains(t, services, "        self.base_url = base_url.rstrip(\"/\") + \"/users\"")
+	require.Contains(t, services, "    def get(self, id: str) -> EntityResponse[User]:\n        \"\"\"Get single user by id\"\"\"\n        response = self.client.request(\"GET\", f\"{self.base_url}/{quote(str(id), safe='')}\",")
+	require.Contains(t, services, "        return TypeAdapter(EntityResponse[User]).validate_python(response.json())")
+	require.Contains(t, services, "    def find(self, search: Optional[str] = None, status: Optional[List[UserStatusCode]] = None, page: Optional[int] = None) -> Any:")
+	require.Contains(t, services, "            params=_query({\"search\": search, \"status\": status, \"page\": page}),")
+	require.Contains(t, services, "    def create(self, user: User) -> EntityResponse[User]:")
+	require.Contains(t, services, "            json=_encode(user),")
+	require.Contains(t, services, "    def upload(self, file: Any, id: str) -> Any:")
+	require.Contains(t, services, "            files={\"fileKey\": file},")
+}
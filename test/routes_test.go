@@ -0,0 +1,124 @@
+package test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/routes"
+)
+
+// Write router source file to the folder
+func writeRouterFile(t *testing.T, folder string, fileName string, source string) string {
+	filePath := path.Join(folder, fileName)
+	require.Nil(t, os.WriteFile(filePath, []byte(source), 0644))
+	return filePath
+}
+
+func TestRoutesScanner(t *testing.T) {
+	dir := t.TempDir()
+	filePath := writeRouterFile(t, dir, "routers.go", `package api
+
+func chiRoutes(r chi.Router) {
+	r.Route("/v2", func(r chi.Router) {
+		r.Get("/orders/{id}", getOrder)
+		r.Method("DELETE", "/orders/{id}", deleteOrder)
+	})
+	r.Post("/login", login)
+}
+
+func gorillaRoutes(r *mux.Router) {
+	s := r.PathPrefix("/v3").Subrouter()
+	s.HandleFunc("/items/{id:[0-9]+}", item).Methods("GET", "PUT")
+}
+
+func ginRoutes(r *gin.Engine) {
+	api := r.Group("/api")
+	api.GET("/users/:id", getUser)
+	r.Group("/admin").Handle("PATCH", "/settings", settings)
+}
+
+func other() {
+	_, _ = http.Get("http://example.com/ping")
+	_ = cache.Get("/key")
+	http.HandleFunc("GET example.com/static/", static)
+}
+`)
+	writeRouterFile(t, dir, "routers_test.go", `package api
+
+func testRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /test", nil)
+}
+`)
+
+	list, err := routes.ScanFolder(dir)
+	require.Nil(t, err)
+
+	found := make([]string, 0)
+	for _, r := range list {
+		found = append(found, r.String())
+	}
+	require.Equal(t, []string{
+		"GET /v2/orders/{id}",
+		"DELETE /v2/orders/{id}",
+		"POST /login",
+		"GET /v3/items/{id:[0-9]+}",
+		"PUT /v3/items/{id:[0-9]+}",
+		"GET /api/users/:id",
+		"PATCH /admin/settings",
+		"GET /static/",
+	}, found)
+	require.Equal(t, filePath+":5", list[0].Location())
+}
+
+func TestRoutesCompare(t *testing.T) {
+	dir := t.TempDir()
+	writeRouterFile(t, dir, "mux.go", `package api
+
+func routes(mux *http.ServeMux, h *Handlers) {
+	mux.HandleFunc("GET /api/users/{userId}", h.get)
+	mux.HandleFunc("GET /api/users/", h.find)
+	mux.HandleFunc("PUT /api/users/{id}", h.delete)
+	mux.HandleFunc("GET /api/health", h.health)
+}
+`)
+	writeRouterFile(t, dir, "gin.go", `package api
+
+func ginRoutes(r *gin.Engine) {
+	users := r.Group("/api").Group("/users")
+	users.POST("", create)
+}
+`)
+
+	registered, err := routes.ScanFolder(dir)
+	require.Nil(t, err)
+
+	drifts := routes.Compare(sampleModel(), registered, "/api")
+	lines := make([]string, 0)
+	for _, d := range drifts {
+		lines = append(lines, d.Kind+" "+d.Name+" "+d.Message)
+	}
+	require.Equal(t, []string{
+		"mismatch UsersService.Delete annotated DELETE /users/{id} but registered PUT /api/users/{id}",
+		"unregistered UsersService.Upload POST /users/{id}/avatar is annotated but not registered",
+		"unannotated  GET /api/health is registered but not annotated",
+	}, lines)
+	require.Equal(t, path.Join(dir, "mux.go")+":7", drifts[2].Location)
+}
+
+func TestRoutesCompareUsedRoute(t *testing.T) {
+	registered := []*routes.Route{{Method: "GET", Path: "/users/{id}", File: "mux.go", Line: 7}}
+
+	// The route of Get is not reported as mismatch of Delete
+	drifts := routes.Compare(sampleModel(), registered, "")
+	for _, d := range drifts {
+		if d.Name == "UsersService.Delete" {
+			require.Equal(t, routes.DriftUnregistered, d.Kind)
+			require.Equal(t, "DELETE /users/{id} is annotated but not registered", d.Message)
+			return
+		}
+	}
+	require.Fail(t, "UsersService.Delete drift is missing")
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/model"
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestSqlProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewSqlProcessor(sampleModel(), outDir).Start()
+	require.Nil(t, err)
+
+	content := readFile(t, path.Join(outDir, "schema.sql"))
+	require.Contains(t, content, `CREATE TABLE IF NOT EXISTS "user" (`)
+	require.Contains(t, content, "    id         TEXT NOT NULL,")
+	require.Contains(t, content, "    status     INTEGER CHECK (status IN (0, 1, 2)),")
+	require.Contains(t, content, "    roles      TEXT[],")
+	require.Contains(t, content, "    props      JSONB,")
+	require.Contains(t, content, "    PRIMARY KEY (id)")
+	require.Contains(t, content, `CREATE UNIQUE INDEX IF NOT EXISTS user_email_key ON "user" (email);`)
+	require.Contains(t, content, `CREATE INDEX IF NOT EXISTS user_status_idx ON "user" (status);`)
+	require.NotContains(t, content, "users_page")
+}
+
+func TestSqlMigrationProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	// First migration creates the tables
+	mm := sampleModel()
+	err := processor.NewSqlMigrationProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+	require.Contains(t, readFile(t, path.Join(outDir, "0001_migration.sql")), `CREATE TABLE IF NOT EXISTS "user" (`)
+	require.FileExists(t, path.Join(outDir, processor.SqlSnapshotFile))
+
+	// No changes, no migration
+	err = processor.NewSqlMigrationProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+	require.NoFileExists(t, path.Join(outDir, "0002_migration.sql"))
+
+	// Drop column, add column and change column type
+	user := mm.GetClass("User")
+	fields := make([]*model.FieldInfo, 0)
+	for _, fi := range user.Fields {
+		if fi.Name != "Email" {
+			fields = append(fields, fi)
+		}
+	}
+	user.Fields = fields
+	user.AddField("Phone", "string", "User phone")
+	user.GetField("Phone").IsNotNull = true
+	user.GetField("Status").Type = "string"
+
+	err = processor.NewSqlMigrationProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+
+	content := readFile(t, path.Join(outDir, "0002_migration.sql"))
+	require.Contains(t, content, "DROP INDEX IF EXISTS user_email_key;")
+	require.Contains(t, content, `ALTER TABLE "user" ADD COLUMN phone TEXT NOT NULL;`)
+	require.Contains(t, content, `ALTER TABLE "user" DROP COLUMN IF EXISTS email;`)
+	require.Contains(t, content, `ALTER TABLE "user" DROP CONSTRAINT IF EXISTS user_status_check;`)
+	require.Contains(t, content, `ALTER TABLE "user" ALTER COLUMN status TYPE TEXT USING status::TEXT;`)
+	require.NotContains(t, content, "CREATE TABLE")
+
+	report := readFile(t, path.Join(outDir, "0002_migration.report.md"))
+	require.Contains(t, report, "`user.email`: column dropped")
+	require.Contains(t, report, "`user.phone`: new NOT NULL column")
+	require.Contains(t, report, "`user.status`: type changed from INTEGER to TEXT")
+
+	// Snapshot is updated
+	err = processor.NewSqlMigrationProcessor(mm, outDir).Start()
+	require.Nil(t, err)
+	require.NoFileExists(t, path.Join(outDir, "0003_migration.sql"))
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-yaaf/yaaf-code-gen/processor"
+)
+
+func TestSwiftProcessor(t *testing.T) {
+	outDir := t.TempDir()
+
+	err := processor.NewSwiftProcessor(sampleModel(), outDir, "AcmeApi").Start()
+	require.Nil(t, err)
+	sources := path.Join(outDir, "Sources", "AcmeApi")
+
+	pkg := readFile(t, path.Join(outDir, "Package.swift"))
+	require.Contains(t, pkg, "// swift-tools-version:5.7\n")
+	require.Contains(t, pkg, "    name: \"AcmeApi\",")
+	require.Contains(t, readFile(t, path.Join(sources, "APIClient.swift")), "public final class APIClient {")
+
+	enum := readFile(t, path.Join(sources, "Models", "UserStatusCode.swift"))
+	require.Contains(t, enum, "public enum UserStatusCode: Int, Codable, CaseIterable, QueryValue {")
+	require.Contains(t, enum, "    /// ACTIVE status\n    case active = 1\n")
+
+	user := readFile(t, path.Join(sources, "Models", "User.swift"))
+	require.Contains(t, user, "/// User entity\npublic struct User: Codable {")
+	require.Contains(t, user, "    public var createdOn: Int64?\n")
+	require.Contains(t, user, "    public var status: UserStatusCode?\n")
+	require.Contains(t, user, "    public var roles: [String]?\n")
+	require.Contains(t, user, "    public var props: [String: JSONValue]?\n")
+	require.Contains(t, user, "        case createdOn = \"createdOn\"\n")
+	require.Contains(t, user, "    public init(id: String? = nil, createdOn: Int64? = nil, name: String? = nil,")
+
+	require.Contains(t, readFile(t, path.Join(sources, "Models", "EntityResponse.swift")), "public struct EntityResponse<T: Codable>: Codable {")
+
+	service := readFile(t, path.Join(sources, "Services", "UsersService.swift"))
+	require.Contains(t, service, "/// - Note: expected HTTP headers (set them in APIClient.headers): X-API-KEY\npublic struct UsersService {")
+	require.Contains(t, service, "    public func get(id: String) async throws -> EntityResponse<User> {\n        return try client.decode(try await client.send(\"GET\", \"/users/\\(APIClient.escape(id))\"))")
+	require.Contains(t, service, "    public func find(search: String? = nil, status: [UserStatusCode]? = nil, page: Int? = nil) async throws -> JSONValue {")
+	require.Contains(t, service, "client.send(\"GET\", \"/users\", query: [\"search\": search, \"status\": status, \"page\": page])")
+	require.Contains(t, service, "client.send(\"POST\", \"/users\", body: user)")
+	require.Contains(t, service, "    public func upload(file: Data, id: String) async throws -> JSONValue {")
+	require.Contains(t, service, "client.send(\"POST\", \"/users/\\(APIClient.escape(id))/avatar\", file: file)")
+}